@@ -0,0 +1,73 @@
+package streams
+
+import (
+	"time"
+)
+
+// Batch groups the elements of the streams into []interface{} of size
+// elements each. The last batch holds whatever is left over, so it may
+// be smaller. Use FlatMap to go the other way.
+func (streams *Streams) Batch(size int) *Streams {
+	return streams.BatchWithTimeout(size, 0)
+}
+
+// BatchWithTimeout is Batch, but a batch that is not full maxWait after its
+// first element arrived is passed on anyway. This keeps slow streams from
+// holding elements back for too long. A maxWait of 0 means no timeout.
+func (streams *Streams) BatchWithTimeout(size int, maxWait time.Duration) *Streams {
+	if size < 1 {
+		size = 1
+	}
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		batch := make([]interface{}, 0, size)
+		// a nil channel is never ready, so there is no deadline until a batch starts
+		var deadline <-chan time.Time
+		var timer *time.Timer
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, deadline = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			full := batch
+			batch = make([]interface{}, 0, size)
+			return life.send(next, full)
+		}
+
+		for {
+			select {
+			case element, ok := <-current:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, element)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					deadline = timer.C
+				}
+				if len(batch) == size && !flush() {
+					return
+				}
+			case <-deadline:
+				timer, deadline = nil, nil
+				if !flush() {
+					return
+				}
+			case <-life.ctx.Done():
+				return
+			case <-life.stop:
+				return
+			}
+		}
+	}()
+
+	return streams
+}
@@ -0,0 +1,69 @@
+package streams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type BatchCase struct {
+	Start    []interface{}
+	Size     int
+	Expected []interface{}
+}
+
+func TestStreams_Batch(t *testing.T) {
+	cases := []BatchCase{
+		{[]interface{}{}, 2, []interface{}{}},
+		{[]interface{}{1, 2, 3}, 0, []interface{}{[]interface{}{1}, []interface{}{2}, []interface{}{3}}},
+		{[]interface{}{1, 2, 3, 4}, 2, []interface{}{[]interface{}{1, 2}, []interface{}{3, 4}}},
+		{[]interface{}{1, 2, 3}, 2, []interface{}{[]interface{}{1, 2}, []interface{}{3}}},
+		{[]interface{}{1, 2, 3}, 5, []interface{}{[]interface{}{1, 2, 3}}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			Batch(caze.Size).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_BatchWithTimeout(t *testing.T) {
+	source := make(Stream)
+	go func() {
+		defer close(source)
+		source <- 1
+		source <- 2
+		source <- 3
+		// long enough for the partial batch of 3 to time out
+		time.Sleep(100 * time.Millisecond)
+		source <- 4
+	}()
+
+	actual := FromStream(source, 0).
+		BatchWithTimeout(2, 40*time.Millisecond).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{[]interface{}{1, 2}, []interface{}{3}, []interface{}{4}}, actual)
+}
+
+func TestStreams_BatchWithTimeoutWaitsForFirstElement(t *testing.T) {
+	source := make(Stream)
+	go func() {
+		defer close(source)
+		// no empty batches are sent while the stream is idle
+		time.Sleep(100 * time.Millisecond)
+		source <- 1
+		source <- 2
+	}()
+
+	actual := FromStream(source, 0).
+		BatchWithTimeout(2, 40*time.Millisecond).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{[]interface{}{1, 2}}, actual)
+}
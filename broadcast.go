@@ -0,0 +1,322 @@
+package streams
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+)
+
+// SlowBranchPolicy decides what Broadcast does with an element when one of
+// its branches is not keeping up and that branch's buffer is full
+type SlowBranchPolicy int
+
+const (
+	// BlockSlowBranches waits for the slow branch, which holds every branch
+	// back to the pace of the slowest one.
+	BlockSlowBranches SlowBranchPolicy = iota
+	// DropForSlowBranches discards the element for the slow branch only.
+	DropForSlowBranches
+	// SpillForSlowBranches writes the element to a temp file, which the slow
+	// branch reads back, in order, once it catches up.
+	SpillForSlowBranches
+)
+
+// BroadcastOptions configures Broadcast. The zero value of each field
+// means the default.
+// BufferSize is the buffer of each branch's first Stream. Defaults to the
+// channelBuffer of the streams, and is also the channelBuffer of each branch.
+// Policy decides what to do about slow branches. Defaults to BlockSlowBranches.
+// Codec and TempDir are used by SpillForSlowBranches, like in SortOptions.
+type BroadcastOptions struct {
+	BufferSize int
+	Policy     SlowBranchPolicy
+	Codec      SpillCodec
+	TempDir    string
+}
+
+// Tee returns n new streams objects that each get every element of the
+// streams, so the same input can feed several independent pipelines.
+// A slow branch holds all of them back; see Broadcast to change that.
+// Do not add anything else to the streams Tee was called on.
+func (streams *Streams) Tee(n int) []*Streams {
+	return streams.Broadcast(n, BroadcastOptions{})
+}
+
+// Broadcast is Tee configured with options. Each branch stops on its own:
+// once every branch has stopped, so do the streams. An error in the streams
+// is reported by every branch; an error in a branch is only reported by
+// that branch. See Err.
+func (streams *Streams) Broadcast(n int, options BroadcastOptions) []*Streams {
+	return streams.fanOut(n, options, func(_ interface{}) int {
+		return toEveryBranch
+	})
+}
+
+// toEveryBranch is returned by the route of fanOut to send an element to
+// every branch
+const toEveryBranch = -1
+
+// fanOut starts n new streams objects, fed by a goroutine that sends each
+// element of the streams to the branch whose index route returns, or to every
+// branch for toEveryBranch. Elements routed to a branch that has stopped are
+// discarded; once every branch has stopped, so do the streams. Unset options
+// get the defaults described in BroadcastOptions.
+func (streams *Streams) fanOut(n int, options BroadcastOptions, route func(element interface{}) int) []*Streams {
+	if options.BufferSize < 1 {
+		options.BufferSize = streams.channelBuffer
+	}
+	if options.Codec == nil {
+		options.Codec = GobCodec{}
+	}
+	current := streams.lastStream()
+	life := streams.lifetime()
+
+	branches := make([]*broadcastBranch, n)
+	result := make([]*Streams, n)
+	for index := range branches {
+		out := make(Stream, options.BufferSize)
+		result[index] = FromStream(out, options.BufferSize).WithContext(streams.ctx)
+		branches[index] = &broadcastBranch{
+			streams: result[index],
+			life:    result[index].lifetime(),
+			out:     out,
+			options: options,
+			live:    true,
+		}
+		if options.Policy == SpillForSlowBranches {
+			branches[index].wake = make(chan struct{}, 1)
+			go branches[index].pump()
+		}
+	}
+
+	go func() {
+		defer streams.halt()
+		defer func() {
+			err := streams.firstFailure()
+			for _, branch := range branches {
+				if err != nil {
+					branch.streams.fail(err)
+				}
+				branch.finish()
+			}
+		}()
+
+		live := n
+		offer := func(branch *broadcastBranch, element interface{}) {
+			if branch.live && !branch.offer(life, element) {
+				branch.live = false
+				live--
+			}
+		}
+		for live > 0 {
+			element, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			if index := route(element); index == toEveryBranch {
+				for _, branch := range branches {
+					offer(branch, element)
+				}
+			} else {
+				offer(branches[index], element)
+			}
+		}
+	}()
+
+	return result
+}
+
+// broadcastBranch feeds one branch of Broadcast. With SpillForSlowBranches,
+// elements that do not fit in out are written to spill by the broadcasting
+// goroutine and read back from reader by pump, which is the one to close out.
+type broadcastBranch struct {
+	streams *Streams
+	life    lifetime
+	out     Stream
+	options BroadcastOptions
+	live    bool
+
+	mutex   sync.Mutex
+	wake    chan struct{}
+	done    bool
+	closed  bool
+	pending int
+	spill   *os.File
+	reader  *os.File
+	writer  *bufio.Writer
+	encoder SpillEncoder
+	decoder SpillDecoder
+}
+
+// offer hands element to the branch according to the policy. Returns false
+// once the branch or the streams have stopped.
+func (branch *broadcastBranch) offer(life lifetime, element interface{}) bool {
+	switch branch.options.Policy {
+	case DropForSlowBranches:
+		select {
+		case branch.out <- element:
+		default:
+		}
+	case SpillForSlowBranches:
+		branch.mutex.Lock()
+		defer branch.mutex.Unlock()
+		if branch.closed {
+			return false
+		}
+		if branch.pending == 0 {
+			select {
+			case branch.out <- element:
+				return !branch.life.stopped()
+			default:
+			}
+		}
+		if err := branch.spillElement(element); err != nil {
+			branch.streams.fail(err)
+			return false
+		}
+		branch.pending++
+		branch.signal()
+		return true
+	default:
+		select {
+		case branch.out <- element:
+		case <-branch.life.stop:
+			return false
+		case <-life.ctx.Done():
+			return false
+		case <-life.stop:
+			return false
+		}
+	}
+	return !branch.life.stopped()
+}
+
+// spillElement appends element to the spill file, creating it if needed.
+// The caller holds the mutex.
+func (branch *broadcastBranch) spillElement(element interface{}) error {
+	if branch.spill == nil {
+		file, err := os.CreateTemp(branch.options.TempDir, "streams-broadcast-*")
+		if err != nil {
+			return err
+		}
+		reader, err := os.Open(file.Name())
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+			return err
+		}
+		branch.spill = file
+		branch.reader = reader
+		branch.writer = bufio.NewWriter(file)
+		branch.encoder = branch.options.Codec.NewEncoder(branch.writer)
+		branch.decoder = branch.options.Codec.NewDecoder(reader)
+	}
+	if err := branch.encoder.Encode(element); err != nil {
+		return err
+	}
+	// flushing every element means pump never reads half an element
+	return branch.writer.Flush()
+}
+
+func (branch *broadcastBranch) signal() {
+	select {
+	case branch.wake <- struct{}{}:
+	default:
+	}
+}
+
+// finish tells the branch no more elements are coming
+func (branch *broadcastBranch) finish() {
+	if branch.options.Policy != SpillForSlowBranches {
+		close(branch.out)
+		return
+	}
+	branch.mutex.Lock()
+	branch.done = true
+	branch.mutex.Unlock()
+	branch.signal()
+}
+
+// pump moves spilled elements into out, in order, then closes out once
+// finish has been called and the spill is empty
+func (branch *broadcastBranch) pump() {
+	defer branch.close()
+	for {
+		branch.mutex.Lock()
+		pending, done := branch.pending, branch.done
+		var element interface{}
+		var err error
+		if pending > 0 {
+			element, err = branch.decoder.Decode()
+		}
+		branch.mutex.Unlock()
+
+		if err != nil {
+			branch.streams.fail(err)
+			return
+		}
+		if pending == 0 {
+			if done {
+				return
+			}
+			select {
+			case <-branch.wake:
+				continue
+			case <-branch.life.stop:
+				return
+			case <-branch.life.ctx.Done():
+				return
+			}
+		}
+		if !branch.life.send(branch.out, element) {
+			return
+		}
+		// only counted once sent, so offer cannot overtake element
+		branch.mutex.Lock()
+		branch.pending--
+		if branch.pending == 0 {
+			err = branch.rewind()
+		}
+		branch.mutex.Unlock()
+		if err != nil {
+			branch.streams.fail(err)
+			return
+		}
+	}
+}
+
+// rewind empties the spill file once everything in it has been sent, so a
+// branch that falls behind now and then does not keep every element it ever
+// spilled on disk. The caller holds the mutex.
+func (branch *broadcastBranch) rewind() error {
+	if err := branch.spill.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := branch.spill.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := branch.reader.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// the codec may buffer or keep state about what it has seen, so both
+	// ends start over, as they would with a new file
+	branch.writer.Reset(branch.spill)
+	branch.encoder = branch.options.Codec.NewEncoder(branch.writer)
+	branch.decoder = branch.options.Codec.NewDecoder(branch.reader)
+	return nil
+}
+
+// close closes out and removes the spill file. Holding the mutex keeps
+// offer from sending to out after it is closed.
+func (branch *broadcastBranch) close() {
+	branch.mutex.Lock()
+	defer branch.mutex.Unlock()
+	branch.closed = true
+	close(branch.out)
+	if branch.spill != nil {
+		_ = branch.spill.Close()
+		_ = branch.reader.Close()
+		_ = os.Remove(branch.spill.Name())
+	}
+}
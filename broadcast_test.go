@@ -0,0 +1,176 @@
+package streams
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+// collectAll collects every branch at the same time, as a slow branch
+// would otherwise hold the others back
+func collectAll(branches []*Streams) ([]interface{}, []error) {
+	results := make([]interface{}, len(branches))
+	errs := make([]error, len(branches))
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(branches))
+	for index, branch := range branches {
+		go func(index int, branch *Streams) {
+			defer waitGroup.Done()
+			results[index], errs[index] = branch.CollectErr(collectors.NewSliceCollector())
+		}(index, branch)
+	}
+	waitGroup.Wait()
+	return results, errs
+}
+
+func TestStreams_Tee(t *testing.T) {
+	cases := [][]interface{}{
+		{},
+		{1, 2, 3},
+	}
+
+	for _, caze := range cases {
+		branches := FromCollection(caze).Tee(3)
+		actual, errs := collectAll(branches)
+
+		assert.Equal(t, []interface{}{caze, caze, caze}, actual)
+		assert.Equal(t, []error{nil, nil, nil}, errs)
+	}
+}
+
+func TestStreams_TeeIndependentPipelines(t *testing.T) {
+	branches := FromCollection([]interface{}{1, 2, 3, 4}).Tee(2)
+	var sum interface{}
+	var evens interface{}
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(2)
+
+	go func() {
+		defer waitGroup.Done()
+		sum = branches[0].Reduce(0, ReduceToSum)
+	}()
+	go func() {
+		defer waitGroup.Done()
+		evens = branches[1].Filter(EvenPredicate).Collect(collectors.NewSliceCollector())
+	}()
+	waitGroup.Wait()
+
+	assert.Equal(t, 10, sum)
+	assert.Equal(t, []interface{}{2, 4}, evens)
+}
+
+func TestStreams_TeeBranchStopsEarly(t *testing.T) {
+	branches := FromCollection([]interface{}{1, 2, 3, 4}).Tee(2)
+	branches[0].Limit(1)
+
+	actual, _ := collectAll(branches)
+
+	assert.Equal(t, []interface{}{[]interface{}{1}, []interface{}{1, 2, 3, 4}}, actual)
+}
+
+func TestStreams_TeePassesOnErrors(t *testing.T) {
+	branches := FromCollection([]interface{}{1, 2, 3, 4}).MapErr(FailOnThree).Tee(2)
+
+	_, errs := collectAll(branches)
+
+	assert.Equal(t, []error{errTest, errTest}, errs)
+}
+
+type BroadcastPolicyCase struct {
+	Policy       SlowBranchPolicy
+	ExpectedSlow []interface{}
+}
+
+func TestStreams_BroadcastPolicy(t *testing.T) {
+	cases := []BroadcastPolicyCase{
+		// only the element that fit in the buffer of the slow branch is kept
+		{DropForSlowBranches, []interface{}{1}},
+		{SpillForSlowBranches, []interface{}{1, 2, 3, 4, 5}},
+	}
+
+	for _, caze := range cases {
+		dir := t.TempDir()
+		branches := FromCollection([]interface{}{1, 2, 3, 4, 5}).
+			Broadcast(2, BroadcastOptions{BufferSize: 1, Policy: caze.Policy, TempDir: dir})
+
+		// the slow branch is not read at all until the fast one is done
+		fast := branches[0].Collect(collectors.NewSliceCollector())
+		slow := branches[1].Collect(collectors.NewSliceCollector())
+
+		// with DropForSlowBranches, the fast branch may fall behind too
+		if caze.Policy != DropForSlowBranches {
+			assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, fast)
+		}
+		assert.Equal(t, 1, fast.([]interface{})[0])
+		assert.Equal(t, caze.ExpectedSlow, slow)
+		spills, _ := os.ReadDir(dir)
+		assert.Empty(t, spills)
+	}
+}
+
+func TestStreams_BroadcastSpillKeepsOrder(t *testing.T) {
+	start := make([]interface{}, 1000)
+	for index := range start {
+		start[index] = index
+	}
+	branches := FromCollection(start).
+		Broadcast(3, BroadcastOptions{BufferSize: 4, Policy: SpillForSlowBranches, TempDir: t.TempDir()})
+
+	actual, _ := collectAll(branches)
+
+	assert.Equal(t, []interface{}{start, start, start}, actual)
+}
+
+// spillSize returns the total size of the spill files in dir
+func spillSize(dir string) int64 {
+	spills, _ := filepath.Glob(filepath.Join(dir, "streams-broadcast-*"))
+	size := int64(0)
+	for _, spill := range spills {
+		if info, err := os.Stat(spill); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+func TestStreams_BroadcastSpillEmptiesOnceCaughtUp(t *testing.T) {
+	dir := t.TempDir()
+	source := make(Stream)
+	branches := FromStream(source, 0).
+		Broadcast(2, BroadcastOptions{BufferSize: 1, Policy: SpillForSlowBranches, TempDir: dir})
+	fast := make(chan interface{})
+	go func() {
+		fast <- branches[0].Collect(collectors.NewSliceCollector())
+	}()
+	slow := branches[1].streams[0]
+	expected := []interface{}{}
+	actual := []interface{}{}
+
+	for round := 0; round < 2; round++ {
+		// the slow branch falls behind, spilling most of these
+		for i := 0; i < 10; i++ {
+			element := round*10 + i
+			source <- element
+			expected = append(expected, element)
+		}
+		assert.Eventually(t, func() bool { return spillSize(dir) > 0 }, time.Second, time.Millisecond)
+
+		// then catches up, which empties the spill file rather than
+		// leaving this round's elements for the next one to add to
+		for i := 0; i < 10; i++ {
+			actual = append(actual, <-slow)
+		}
+		assert.Eventually(t, func() bool { return spillSize(dir) == 0 }, time.Second, time.Millisecond)
+	}
+	close(source)
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expected, <-fast)
+	_, ok := <-slow
+	assert.False(t, ok)
+}
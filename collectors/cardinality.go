@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// DefaultPrecision is the precision of a CardinalityCollector when none is
+// given. It uses 16KB and estimates within about 1% of the distinct count.
+const DefaultPrecision = 14
+
+// HashFunc hashes an element to 64 bits. Equal elements must have equal hashes.
+type HashFunc func(element interface{}) uint64
+
+// HashElement is the default HashFunc. It hashes an element using its %#v
+// formatting, which includes its type, so it works for any element but is
+// slower than a HashFunc written for the element type.
+func HashElement(element interface{}) uint64 {
+	hasher := fnv.New64a()
+	_, _ = fmt.Fprintf(hasher, "%#v", element)
+	return hasher.Sum64()
+}
+
+// CardinalityOptions configures a CardinalityCollector. The zero value
+// uses the DefaultPrecision and HashElement.
+type CardinalityOptions struct {
+	// Precision is the number of hash bits used to pick a register, from 4
+	// to 18. The collector keeps 2^Precision registers and its standard
+	// error is about 1.04 / sqrt(2^Precision).
+	Precision uint8
+	// Hash hashes the elements. Collectors that are combined, or whose
+	// results are merged, must use the same Hash.
+	Hash HashFunc
+}
+
+// CardinalityCollector estimates the number of distinct elements using
+// HyperLogLog, in a fixed amount of memory however many there are.
+type CardinalityCollector struct {
+	options   CardinalityOptions
+	registers []uint8
+}
+
+// NewCardinalityCollector creates an empty CardinalityCollector with
+// the default options and returns a pointer to it.
+func NewCardinalityCollector() *CardinalityCollector {
+	return NewCardinalityCollectorWith(CardinalityOptions{})
+}
+
+// NewCardinalityCollectorWith creates an empty CardinalityCollector with
+// the given options and returns a pointer to it.
+func NewCardinalityCollectorWith(options CardinalityOptions) *CardinalityCollector {
+	if options.Precision == 0 {
+		options.Precision = DefaultPrecision
+	}
+	if options.Precision < 4 || options.Precision > 18 {
+		panic(fmt.Sprintf("collectors: precision %d is not between 4 and 18", options.Precision))
+	}
+	if options.Hash == nil {
+		options.Hash = HashElement
+	}
+	collector := CardinalityCollector{options, make([]uint8, 1<<options.Precision)}
+	return &collector
+}
+
+// Add adds the element to the estimate
+func (collector *CardinalityCollector) Add(element interface{}) {
+	// mixing spreads out the bits of weak hashes, like those of small ints
+	hash := mix(collector.options.Hash(element))
+	precision := collector.options.Precision
+	register := hash >> (64 - precision)
+	// the marker bit caps the rank for a remainder of all zeros
+	rank := uint8(bits.LeadingZeros64(hash<<precision|1<<(precision-1))) + 1
+	if rank > collector.registers[register] {
+		collector.registers[register] = rank
+	}
+}
+
+// Complete returns a HyperLogLog holding the estimate
+func (collector *CardinalityCollector) Complete() interface{} {
+	return HyperLogLog{append([]uint8{}, collector.registers...)}
+}
+
+// Supplier returns a new CardinalityCollector with the same options
+func (collector *CardinalityCollector) Supplier() Collector {
+	return NewCardinalityCollectorWith(collector.options)
+}
+
+// Combine adds the elements seen by other, which must be a
+// CardinalityCollector with the same options
+func (collector *CardinalityCollector) Combine(other Collector) {
+	mergeRegisters(collector.registers, other.(*CardinalityCollector).registers)
+}
+
+// HyperLogLog is the result of CardinalityCollector. Results of separate
+// streams can be merged to estimate the distinct elements of them all.
+type HyperLogLog struct {
+	registers []uint8
+}
+
+// Estimate returns the estimated number of distinct elements
+func (hyperLogLog HyperLogLog) Estimate() int {
+	registers := float64(len(hyperLogLog.registers))
+	sum, zeros := 0.0, 0.0
+	for _, rank := range hyperLogLog.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/registers)
+	estimate := alpha * registers * registers / sum
+	// linear counting is more accurate while many registers are empty
+	if estimate <= 2.5*registers && zeros > 0 {
+		estimate = registers * math.Log(registers/zeros)
+	}
+	return int(math.Round(estimate))
+}
+
+// Merge returns a HyperLogLog of the elements of both. Both must come from
+// collectors with the same options.
+func (hyperLogLog HyperLogLog) Merge(other HyperLogLog) HyperLogLog {
+	registers := append([]uint8{}, hyperLogLog.registers...)
+	mergeRegisters(registers, other.registers)
+	return HyperLogLog{registers}
+}
+
+// mergeRegisters keeps the larger rank of each register in registers
+func mergeRegisters(registers, other []uint8) {
+	if len(registers) != len(other) {
+		panic("collectors: cannot merge HyperLogLogs of different precisions")
+	}
+	for i, rank := range other {
+		if rank > registers[i] {
+			registers[i] = rank
+		}
+	}
+}
+
+// mix is the 64 bit finalizer of MurmurHash3
+func mix(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
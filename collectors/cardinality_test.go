@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+// distinctStrings returns n distinct strings, each repeated times times
+func distinctStrings(n, times int) []interface{} {
+	elements := make([]interface{}, 0, n*times)
+	for repeat := 0; repeat < times; repeat++ {
+		for i := 0; i < n; i++ {
+			elements = append(elements, fmt.Sprintf("user-%d", i))
+		}
+	}
+	return elements
+}
+
+type CardinalityCollectorCase struct {
+	Start    []interface{}
+	Expected int
+	Delta    float64
+}
+
+func TestCardinalityCollector(t *testing.T) {
+	cases := []CardinalityCollectorCase{
+		{[]interface{}{}, 0, 0},
+		{[]interface{}{"a", "b", "a", 1, "1"}, 4, 0},
+		{distinctStrings(1000, 3), 1000, 10},
+		{distinctStrings(100000, 2), 100000, 3000},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewCardinalityCollector()).(HyperLogLog)
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(3, NewCardinalityCollector()).(HyperLogLog)
+
+		assert.InDelta(t, caze.Expected, actual.Estimate(), caze.Delta)
+		assert.Equal(t, actual, parallel)
+	}
+}
+
+func TestCardinalityCollectorWith(t *testing.T) {
+	hashed := 0
+	hash := func(element interface{}) uint64 {
+		hashed++
+		return uint64(element.(int))
+	}
+	elements := make([]interface{}, 50000)
+	for i := range elements {
+		elements[i] = i % 20000
+	}
+
+	actual := streams.FromCollection(elements).
+		Collect(NewCardinalityCollectorWith(CardinalityOptions{Precision: 10, Hash: hash})).(HyperLogLog)
+
+	assert.Equal(t, 50000, hashed)
+	assert.Len(t, actual.registers, 1024)
+	assert.InDelta(t, 20000, actual.Estimate(), 2000)
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	first := streams.FromCollection(distinctStrings(3000, 1)).
+		Collect(NewCardinalityCollector()).(HyperLogLog)
+	second := streams.FromCollection(distinctStrings(6000, 1)).
+		Collect(NewCardinalityCollector()).(HyperLogLog)
+	small := streams.FromCollection(distinctStrings(10, 1)).
+		Collect(NewCardinalityCollectorWith(CardinalityOptions{Precision: 4})).(HyperLogLog)
+
+	merged := first.Merge(second)
+
+	assert.InDelta(t, 6000, merged.Estimate(), 120)
+	assert.Equal(t, second.Estimate(), merged.Estimate())
+	assert.InDelta(t, 3000, first.Estimate(), 60)
+	assert.Panics(t, func() { first.Merge(small) })
+}
+
+func TestCardinalityCollectorPanicsOnBadPrecision(t *testing.T) {
+	assert.Panics(t, func() { NewCardinalityCollectorWith(CardinalityOptions{Precision: 3}) })
+	assert.Panics(t, func() { NewCardinalityCollectorWith(CardinalityOptions{Precision: 19}) })
+}
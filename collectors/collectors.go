@@ -1,5 +1,25 @@
 package collectors
 
+import (
+	"fmt"
+)
+
+// Collector is the same type as streams.Collector. It is declared here so that
+// this package does not import streams, which lets the tests of streams use
+// these collectors. Supplier and Combine use it to implement
+// streams.ConcurrentCollector.
+type Collector = interface {
+	Add(subject interface{})
+	Complete() interface{}
+}
+
+// concurrentCollector is the same as streams.ConcurrentCollector
+type concurrentCollector interface {
+	Collector
+	Supplier() Collector
+	Combine(other Collector)
+}
+
 // SliceCollector collects the elements of the stream in a slice
 type SliceCollector struct {
 	collection []interface{}
@@ -23,16 +43,43 @@ func (collector *SliceCollector) Complete() interface{} {
 	return collector.collection
 }
 
+// Supplier returns a new, empty SliceCollector
+func (collector *SliceCollector) Supplier() Collector {
+	return NewSliceCollector()
+}
+
+// Combine appends the slice of other, which must be a SliceCollector
+func (collector *SliceCollector) Combine(other Collector) {
+	collector.collection = append(collector.collection, other.(*SliceCollector).collection...)
+}
+
 // MapCollector collects Entries into a map. Use mappers.KeyValueMapper
 // to map to a stream of collectors.Entry
 type MapCollector struct {
 	collection map[interface{}]interface{}
+	duplicates duplicates
 }
 
 // NewMapCollector creates a new MapCollector with an empty map
 // and returns a pointer to it.
 func NewMapCollector() *MapCollector {
-	collector := MapCollector{map[interface{}]interface{}{}}
+	collector := MapCollector{map[interface{}]interface{}{}, duplicates{}}
+	return &collector
+}
+
+// NewMapCollectorWithMerge creates a new MapCollector with an empty map
+// and returns a pointer to it. When a key is added more than once, merge
+// decides its value.
+func NewMapCollectorWithMerge(merge MergeFunc) *MapCollector {
+	collector := MapCollector{map[interface{}]interface{}{}, duplicates{merge: merge}}
+	return &collector
+}
+
+// NewStrictMapCollector creates a new MapCollector with an empty map
+// and returns a pointer to it. When a key is added more than once, the
+// first value is kept and CompleteErr returns a DuplicateKeyError.
+func NewStrictMapCollector() *MapCollector {
+	collector := MapCollector{map[interface{}]interface{}{}, duplicates{strict: true}}
 	return &collector
 }
 
@@ -43,11 +90,61 @@ type Entry struct {
 	Value interface{}
 }
 
+// MergeFunc decides the value of a key that is added to a map collector more
+// than once. old is the value thus far and new is the value being added.
+type MergeFunc func(old, new interface{}) interface{}
+
+// DuplicateKeyError is returned by CompleteErr of the strict map collectors
+// for the first key that was added more than once.
+type DuplicateKeyError struct {
+	Key interface{}
+}
+
+func (err DuplicateKeyError) Error() string {
+	return fmt.Sprintf("collectors: duplicate key %v", err.Key)
+}
+
+// duplicates decides what the map collectors do with keys added more than once
+type duplicates struct {
+	merge  MergeFunc
+	strict bool
+	err    error
+}
+
+// resolve returns the value for key, which already has the value old,
+// now that new is being added
+func (rules *duplicates) resolve(key, old, new interface{}) interface{} {
+	switch {
+	case rules.strict:
+		if rules.err == nil {
+			rules.err = DuplicateKeyError{key}
+		}
+		return old
+	case rules.merge != nil:
+		return rules.merge(old, new)
+	default:
+		return new
+	}
+}
+
+// fresh returns the same rules without any error seen thus far
+func (rules *duplicates) fresh() duplicates {
+	return duplicates{merge: rules.merge, strict: rules.strict}
+}
+
 // Add adds an entry to the map. If there is an existing entry with the
-// same key, it will be overwritten.
+// same key, it will be overwritten, unless the collector was created with
+// NewMapCollectorWithMerge or NewStrictMapCollector.
 func (collector *MapCollector) Add(entry interface{}) {
 	asEntry := entry.(Entry)
-	collector.collection[asEntry.Key] = asEntry.Value
+	collector.put(asEntry.Key, asEntry.Value)
+}
+
+func (collector *MapCollector) put(key, value interface{}) {
+	if old, exists := collector.collection[key]; exists {
+		value = collector.duplicates.resolve(key, old, value)
+	}
+	collector.collection[key] = value
 }
 
 // Complete returns the map that Add has populated
@@ -55,6 +152,114 @@ func (collector *MapCollector) Complete() interface{} {
 	return collector.collection
 }
 
+// CompleteErr returns the map that Add has populated, and for a collector
+// created with NewStrictMapCollector, a DuplicateKeyError if a key was
+// added more than once. streams.CollectErr returns that error.
+func (collector *MapCollector) CompleteErr() (interface{}, error) {
+	return collector.collection, collector.duplicates.err
+}
+
+// Supplier returns a new, empty MapCollector that handles duplicate keys
+// the same way
+func (collector *MapCollector) Supplier() Collector {
+	supplied := MapCollector{map[interface{}]interface{}{}, collector.duplicates.fresh()}
+	return &supplied
+}
+
+// Combine adds the entries of other, which must be a MapCollector, as if they
+// were passed to Add.
+func (collector *MapCollector) Combine(other Collector) {
+	asMap := other.(*MapCollector)
+	if collector.duplicates.err == nil {
+		collector.duplicates.err = asMap.duplicates.err
+	}
+	for key, value := range asMap.collection {
+		collector.put(key, value)
+	}
+}
+
+// OrderedMapCollector collects Entries like MapCollector, but keeps the keys
+// in the order they were first added. Use mappers.KeyValueMapper to map to a
+// stream of collectors.Entry
+type OrderedMapCollector struct {
+	entries    []Entry
+	index      map[interface{}]int
+	duplicates duplicates
+}
+
+// NewOrderedMapCollector creates a new, empty OrderedMapCollector and returns
+// a pointer to it. When a key is added more than once, its value is
+// overwritten, but it keeps its place.
+func NewOrderedMapCollector() *OrderedMapCollector {
+	return newOrderedMapCollector(duplicates{})
+}
+
+// NewOrderedMapCollectorWithMerge is NewOrderedMapCollector, but when a key
+// is added more than once, merge decides its value.
+func NewOrderedMapCollectorWithMerge(merge MergeFunc) *OrderedMapCollector {
+	return newOrderedMapCollector(duplicates{merge: merge})
+}
+
+// NewStrictOrderedMapCollector is NewOrderedMapCollector, but when a key is
+// added more than once, the first value is kept and CompleteErr returns a
+// DuplicateKeyError.
+func NewStrictOrderedMapCollector() *OrderedMapCollector {
+	return newOrderedMapCollector(duplicates{strict: true})
+}
+
+func newOrderedMapCollector(duplicates duplicates) *OrderedMapCollector {
+	collector := OrderedMapCollector{[]Entry{}, map[interface{}]int{}, duplicates}
+	return &collector
+}
+
+// Add adds an entry to the collector. See the constructors for what
+// happens to keys that are added more than once.
+func (collector *OrderedMapCollector) Add(entry interface{}) {
+	asEntry := entry.(Entry)
+	collector.put(asEntry.Key, asEntry.Value)
+}
+
+func (collector *OrderedMapCollector) put(key, value interface{}) {
+	if index, exists := collector.index[key]; exists {
+		old := collector.entries[index].Value
+		collector.entries[index].Value = collector.duplicates.resolve(key, old, value)
+		return
+	}
+	collector.index[key] = len(collector.entries)
+	collector.entries = append(collector.entries, Entry{key, value})
+}
+
+// Complete returns a []Entry with one Entry per key, in the order
+// the keys were first added.
+func (collector *OrderedMapCollector) Complete() interface{} {
+	return collector.entries
+}
+
+// CompleteErr returns the same []Entry as Complete, and for a collector
+// created with NewStrictOrderedMapCollector, a DuplicateKeyError if a key
+// was added more than once. streams.CollectErr returns that error.
+func (collector *OrderedMapCollector) CompleteErr() (interface{}, error) {
+	return collector.entries, collector.duplicates.err
+}
+
+// Supplier returns a new, empty OrderedMapCollector that handles duplicate
+// keys the same way
+func (collector *OrderedMapCollector) Supplier() Collector {
+	return newOrderedMapCollector(collector.duplicates.fresh())
+}
+
+// Combine adds the entries of other, which must be an OrderedMapCollector,
+// as if they were passed to Add after every entry of the collector.
+func (collector *OrderedMapCollector) Combine(other Collector) {
+	asOrdered := other.(*OrderedMapCollector)
+	if collector.duplicates.err == nil {
+		collector.duplicates.err = asOrdered.duplicates.err
+	}
+	for _, entry := range asOrdered.entries {
+		collector.put(entry.Key, entry.Value)
+	}
+}
+
 // GroupByCollector collects Entries into a map where the keys are
 // the keys of the Entries and the values are a slice of all the values
 // for that key. Use mappers.KeyValueMapper to map to a stream of
@@ -85,3 +290,16 @@ func (collector *GroupByCollector) Add(entry interface{}) {
 func (collector *GroupByCollector) Complete() interface{} {
 	return collector.collection
 }
+
+// Supplier returns a new, empty GroupByCollector
+func (collector *GroupByCollector) Supplier() Collector {
+	return NewGroupByCollector()
+}
+
+// Combine appends the values of each key in other, which must be a
+// GroupByCollector, to the values for that key.
+func (collector *GroupByCollector) Combine(other Collector) {
+	for key, values := range other.(*GroupByCollector).collection {
+		collector.collection[key] = append(collector.collection[key], values...)
+	}
+}
@@ -3,6 +3,7 @@ package collectors
 import (
 	"github.com/Luke-Sikina/streams"
 	"github.com/stretchr/testify/assert"
+	"sort"
 	"testing"
 )
 
@@ -50,6 +51,87 @@ func TestMapCollector(t *testing.T) {
 	}
 }
 
+func sum(old, new interface{}) interface{} {
+	return old.(int) + new.(int)
+}
+
+func TestMapCollectorWithMerge(t *testing.T) {
+	cases := []MapCollectorCase{
+		{
+			[]interface{}{},
+			map[interface{}]interface{}{},
+		}, {
+			[]interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}, Entry{"a", 4}},
+			map[interface{}]interface{}{"a": 8, "b": 2},
+		},
+	}
+
+	for _, caze := range cases {
+		subject := streams.FromCollection(caze.Entries)
+		actual := subject.Collect(NewMapCollectorWithMerge(sum))
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStrictMapCollector(t *testing.T) {
+	actual, err := streams.FromCollection([]interface{}{Entry{"a", 1}, Entry{"b", 2}}).
+		CollectErr(NewStrictMapCollector())
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"a": 1, "b": 2}, actual)
+
+	actual, err = streams.FromCollection([]interface{}{Entry{"a", 1}, Entry{"a", 2}, Entry{"b", 3}}).
+		CollectErr(NewStrictMapCollector())
+
+	assert.Equal(t, DuplicateKeyError{"a"}, err)
+	assert.Equal(t, map[interface{}]interface{}{"a": 1, "b": 3}, actual)
+}
+
+type OrderedMapCollectorCase struct {
+	Entries  []interface{}
+	Expected []Entry
+}
+
+func TestOrderedMapCollector(t *testing.T) {
+	cases := []OrderedMapCollectorCase{
+		{
+			[]interface{}{},
+			[]Entry{},
+		}, {
+			[]interface{}{Entry{"c", 1}, Entry{"a", 2}, Entry{"b", 3}},
+			[]Entry{{"c", 1}, {"a", 2}, {"b", 3}},
+		}, {
+			[]interface{}{Entry{"c", 1}, Entry{"a", 2}, Entry{"c", 3}},
+			[]Entry{{"c", 3}, {"a", 2}},
+		},
+	}
+
+	for _, caze := range cases {
+		subject := streams.FromCollection(caze.Entries)
+		actual := subject.Collect(NewOrderedMapCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestOrderedMapCollectorWithMerge(t *testing.T) {
+	entries := []interface{}{Entry{"c", 1}, Entry{"a", 2}, Entry{"c", 3}}
+
+	actual := streams.FromCollection(entries).Collect(NewOrderedMapCollectorWithMerge(sum))
+
+	assert.Equal(t, []Entry{{"c", 4}, {"a", 2}}, actual)
+}
+
+func TestStrictOrderedMapCollector(t *testing.T) {
+	entries := []interface{}{Entry{"c", 1}, Entry{"a", 2}, Entry{"c", 3}}
+
+	actual, err := streams.FromCollection(entries).CollectErr(NewStrictOrderedMapCollector())
+
+	assert.Equal(t, DuplicateKeyError{"c"}, err)
+	assert.Equal(t, []Entry{{"c", 1}, {"a", 2}}, actual)
+}
+
 type GroupByCollectorCase struct {
 	Entries  []interface{}
 	Expected map[interface{}][]interface{}
@@ -76,3 +158,67 @@ func TestGroupByCollector(t *testing.T) {
 		assert.Equal(t, caze.Expected, actual)
 	}
 }
+
+func sortedInts(elements []interface{}) []interface{} {
+	sorted := append([]interface{}{}, elements...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].(int) < sorted[j].(int) })
+	return sorted
+}
+
+func TestSliceCollector_ParallelCollect(t *testing.T) {
+	cases := [][]interface{}{
+		{},
+		{1, 2, 3},
+		{8, 7, 6, 5, 4, 3, 2, 1},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze).ParallelCollect(3, NewSliceCollector())
+
+		assert.Equal(t, sortedInts(caze), sortedInts(actual.([]interface{})))
+	}
+}
+
+func TestMapCollector_ParallelCollect(t *testing.T) {
+	entries := []interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"c", 3}, Entry{"d", 4}}
+
+	actual := streams.FromCollection(entries).ParallelCollect(3, NewMapCollector())
+
+	assert.Equal(t, map[interface{}]interface{}{"a": 1, "b": 2, "c": 3, "d": 4}, actual)
+}
+
+func TestGroupByCollector_ParallelCollect(t *testing.T) {
+	entries := []interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}, Entry{"a", 4}, Entry{"b", 5}}
+
+	actual := streams.FromCollection(entries).
+		ParallelCollect(3, NewGroupByCollector()).(map[interface{}][]interface{})
+
+	assert.Equal(t, []interface{}{1, 3, 4}, sortedInts(actual["a"]))
+	assert.Equal(t, []interface{}{2, 5}, sortedInts(actual["b"]))
+	assert.Len(t, actual, 2)
+}
+
+func TestMapCollectorWithMerge_ParallelCollect(t *testing.T) {
+	entries := []interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}, Entry{"a", 4}, Entry{"b", 5}}
+
+	actual := streams.FromCollection(entries).ParallelCollect(3, NewMapCollectorWithMerge(sum))
+
+	assert.Equal(t, map[interface{}]interface{}{"a": 8, "b": 7}, actual)
+}
+
+func TestStrictMapCollector_ParallelCollectErr(t *testing.T) {
+	entries := []interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"c", 3}, Entry{"a", 4}}
+
+	_, err := streams.FromCollection(entries).ParallelCollectErr(3, NewStrictMapCollector())
+
+	assert.Equal(t, DuplicateKeyError{"a"}, err)
+}
+
+func TestOrderedMapCollector_ParallelCollect(t *testing.T) {
+	entries := []interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}}
+
+	actual := streams.FromCollection(entries).
+		ParallelCollect(3, NewOrderedMapCollectorWithMerge(sum)).([]Entry)
+
+	assert.ElementsMatch(t, []Entry{{"a", 4}, {"b", 2}}, actual)
+}
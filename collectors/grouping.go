@@ -0,0 +1,62 @@
+package collectors
+
+// GroupingByCollector collects Entries into a map where the keys are the keys
+// of the Entries, like GroupByCollector. Instead of keeping every value, the
+// values for each key are added to a downstream collector for that key, so
+// only what the downstream collectors keep is held in memory. Use
+// mappers.KeyValueMapper to map to a stream of collectors.Entry
+type GroupingByCollector struct {
+	newDownstream func() Collector
+	downstreams   map[interface{}]Collector
+}
+
+// NewGroupingByCollector creates a new GroupingByCollector with an empty map
+// and returns a pointer to it. newDownstream is called to create the
+// downstream collector the first time each key is seen. For example, to count
+// the values for each key:
+//
+//	NewGroupingByCollector(func() streams.Collector { return NewCountingCollector() })
+func NewGroupingByCollector(newDownstream func() Collector) *GroupingByCollector {
+	collector := GroupingByCollector{newDownstream, map[interface{}]Collector{}}
+	return &collector
+}
+
+// Add adds the value of the entry to the downstream collector for its key
+func (collector *GroupingByCollector) Add(entry interface{}) {
+	asEntry := entry.(Entry)
+	downstream, exists := collector.downstreams[asEntry.Key]
+	if !exists {
+		downstream = collector.newDownstream()
+		collector.downstreams[asEntry.Key] = downstream
+	}
+	downstream.Add(asEntry.Value)
+}
+
+// Complete returns a map[interface{}]interface{} from each key to
+// the result of Complete on its downstream collector.
+func (collector *GroupingByCollector) Complete() interface{} {
+	completed := make(map[interface{}]interface{}, len(collector.downstreams))
+	for key, downstream := range collector.downstreams {
+		completed[key] = downstream.Complete()
+	}
+	return completed
+}
+
+// Supplier returns a new, empty GroupingByCollector with the same downstream
+// collectors. It can only be used with streams.ParallelCollect if the
+// downstream collectors are streams.ConcurrentCollectors.
+func (collector *GroupingByCollector) Supplier() Collector {
+	return NewGroupingByCollector(collector.newDownstream)
+}
+
+// Combine combines the downstream collector of each key in other, which must
+// be a GroupingByCollector, into the downstream collector for that key.
+func (collector *GroupingByCollector) Combine(other Collector) {
+	for key, theirs := range other.(*GroupingByCollector).downstreams {
+		if mine, exists := collector.downstreams[key]; exists {
+			mine.(concurrentCollector).Combine(theirs)
+		} else {
+			collector.downstreams[key] = theirs
+		}
+	}
+}
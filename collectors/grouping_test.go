@@ -0,0 +1,63 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+type GroupingByCollectorCase struct {
+	Entries       []interface{}
+	NewDownstream func() streams.Collector
+	Expected      map[interface{}]interface{}
+}
+
+func TestGroupingByCollector(t *testing.T) {
+	counting := func() streams.Collector { return NewCountingCollector() }
+	summing := func() streams.Collector { return NewSummingCollector() }
+	cases := []GroupingByCollectorCase{
+		{
+			[]interface{}{},
+			counting,
+			map[interface{}]interface{}{},
+		}, {
+			[]interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}},
+			counting,
+			map[interface{}]interface{}{"a": 2, "b": 1},
+		}, {
+			[]interface{}{Entry{"a", 1}, Entry{"b", 2}, Entry{"a", 3}},
+			summing,
+			map[interface{}]interface{}{"a": 4, "b": 2},
+		},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Entries).Collect(NewGroupingByCollector(caze.NewDownstream))
+		parallel := streams.FromCollection(caze.Entries).ParallelCollect(2, NewGroupingByCollector(caze.NewDownstream))
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestGroupingByCollectorNested(t *testing.T) {
+	// country -> city -> visits
+	entries := []interface{}{
+		Entry{"ca", Entry{"toronto", 1}},
+		Entry{"ca", Entry{"ottawa", 1}},
+		Entry{"ca", Entry{"toronto", 1}},
+		Entry{"us", Entry{"boston", 1}},
+	}
+	byCity := func() streams.Collector {
+		return NewGroupingByCollector(func() streams.Collector { return NewCountingCollector() })
+	}
+
+	actual := streams.FromCollection(entries).Collect(NewGroupingByCollector(byCity))
+
+	expected := map[interface{}]interface{}{
+		"ca": map[interface{}]interface{}{"toronto": 2, "ottawa": 1},
+		"us": map[interface{}]interface{}{"boston": 1},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"math"
+	"sort"
+)
+
+// HistogramCollector counts int, int64 and float64 elements into buckets,
+// without keeping the elements.
+type HistogramCollector struct {
+	histogram Histogram
+}
+
+// NewHistogramCollector creates an empty HistogramCollector and returns a
+// pointer to it. bounds are the inclusive upper bounds of the buckets, in
+// ascending order. Elements above the last bound go in an overflow bucket.
+func NewHistogramCollector(bounds []float64) *HistogramCollector {
+	if !sort.Float64sAreSorted(bounds) {
+		panic("collectors: histogram bounds are not in ascending order")
+	}
+	collector := HistogramCollector{Histogram{
+		Bounds: append([]float64{}, bounds...),
+		Counts: make([]int, len(bounds)+1),
+		Min:    math.Inf(1),
+		Max:    math.Inf(-1),
+	}}
+	return &collector
+}
+
+// NewFixedHistogramCollector creates a HistogramCollector with count
+// buckets of the same width, the first of which has an upper bound of start.
+func NewFixedHistogramCollector(start, width float64, count int) *HistogramCollector {
+	if width <= 0 || count <= 0 {
+		panic("collectors: non-positive histogram width or count")
+	}
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start + width*float64(i)
+	}
+	return NewHistogramCollector(bounds)
+}
+
+// NewExponentialHistogramCollector creates a HistogramCollector with count
+// buckets, the first of which has an upper bound of start, where each upper
+// bound is factor times the last. It suits latencies and other elements
+// spread over several orders of magnitude.
+func NewExponentialHistogramCollector(start, factor float64, count int) *HistogramCollector {
+	if start <= 0 || factor <= 1 || count <= 0 {
+		panic("collectors: non-positive histogram start or count, or factor of at most 1")
+	}
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start * math.Pow(factor, float64(i))
+	}
+	return NewHistogramCollector(bounds)
+}
+
+// Add counts the element, which must be an int, int64 or float64, in the
+// first bucket whose upper bound is at least the element
+func (collector *HistogramCollector) Add(element interface{}) {
+	value := toFloat(element)
+	histogram := &collector.histogram
+	histogram.Counts[sort.SearchFloat64s(histogram.Bounds, value)]++
+	histogram.Count++
+	histogram.Min = math.Min(histogram.Min, value)
+	histogram.Max = math.Max(histogram.Max, value)
+}
+
+// Complete returns the Histogram of the elements
+func (collector *HistogramCollector) Complete() interface{} {
+	histogram := collector.histogram
+	histogram.Counts = append([]int{}, histogram.Counts...)
+	if histogram.Count == 0 {
+		histogram.Min, histogram.Max = 0, 0
+	}
+	return histogram
+}
+
+// Supplier returns a new HistogramCollector with the same buckets
+func (collector *HistogramCollector) Supplier() Collector {
+	return NewHistogramCollector(collector.histogram.Bounds)
+}
+
+// Combine adds the counts of other, which must be a HistogramCollector
+// with the same buckets
+func (collector *HistogramCollector) Combine(other Collector) {
+	mine, theirs := &collector.histogram, other.(*HistogramCollector).histogram
+	for i, count := range theirs.Counts {
+		mine.Counts[i] += count
+	}
+	mine.Count += theirs.Count
+	mine.Min = math.Min(mine.Min, theirs.Min)
+	mine.Max = math.Max(mine.Max, theirs.Max)
+}
+
+// Histogram is the result of HistogramCollector. Counts[i] is the number of
+// elements at most Bounds[i] and above Bounds[i-1]; the last count is the
+// overflow bucket. Min and Max are 0 for an empty stream.
+type Histogram struct {
+	Bounds []float64
+	Counts []int
+	Count  int
+	Min    float64
+	Max    float64
+}
+
+// Quantile estimates the value below which q of the elements fall, for q
+// between 0 and 1, assuming the elements are spread evenly within each
+// bucket. It returns NaN for an empty stream or a q outside of [0, 1].
+func (histogram Histogram) Quantile(q float64) float64 {
+	if histogram.Count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	rank := q * float64(histogram.Count)
+	seen := 0.0
+	for i, count := range histogram.Counts {
+		if count == 0 || seen+float64(count) < rank {
+			seen += float64(count)
+			continue
+		}
+		lower, upper := histogram.Min, histogram.Max
+		if i > 0 {
+			lower = math.Max(lower, histogram.Bounds[i-1])
+		}
+		if i < len(histogram.Bounds) {
+			upper = math.Min(upper, histogram.Bounds[i])
+		}
+		return interpolate(rank, seen, seen+float64(count), lower, upper)
+	}
+	return histogram.Max
+}
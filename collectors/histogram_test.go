@@ -0,0 +1,67 @@
+package collectors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+type HistogramCollectorCase struct {
+	Collector *HistogramCollector
+	Start     []interface{}
+	Expected  Histogram
+}
+
+func TestHistogramCollector(t *testing.T) {
+	cases := []HistogramCollectorCase{
+		{
+			NewHistogramCollector([]float64{1, 10}),
+			[]interface{}{},
+			Histogram{[]float64{1, 10}, []int{0, 0, 0}, 0, 0, 0},
+		}, {
+			NewHistogramCollector([]float64{1, 10}),
+			[]interface{}{0.5, 1, 2, 10, 11, int64(100)},
+			Histogram{[]float64{1, 10}, []int{2, 2, 2}, 6, 0.5, 100},
+		}, {
+			NewFixedHistogramCollector(10, 10, 3),
+			[]interface{}{5, 15, 25, 26, 35},
+			Histogram{[]float64{10, 20, 30}, []int{1, 1, 2, 1}, 5, 5, 35},
+		}, {
+			NewExponentialHistogramCollector(1, 10, 3),
+			[]interface{}{0.5, 5, 50, 60, 500},
+			Histogram{[]float64{1, 10, 100}, []int{1, 1, 2, 1}, 5, 0.5, 500},
+		},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(caze.Collector)
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(2, caze.Collector.Supplier().(streams.ConcurrentCollector))
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestHistogram_Quantile(t *testing.T) {
+	elements := make([]interface{}, 100)
+	for i := range elements {
+		elements[i] = i + 1
+	}
+
+	histogram := streams.FromCollection(elements).
+		Collect(NewFixedHistogramCollector(10, 10, 10)).(Histogram)
+
+	assert.Equal(t, 1.0, histogram.Quantile(0))
+	assert.Equal(t, 50.0, histogram.Quantile(0.5))
+	assert.Equal(t, 95.0, histogram.Quantile(0.95))
+	assert.Equal(t, 100.0, histogram.Quantile(1))
+	assert.True(t, math.IsNaN(histogram.Quantile(-1)))
+}
+
+func TestHistogramCollectorPanicsOnBadBuckets(t *testing.T) {
+	assert.Panics(t, func() { NewHistogramCollector([]float64{10, 1}) })
+	assert.Panics(t, func() { NewFixedHistogramCollector(0, 0, 3) })
+	assert.Panics(t, func() { NewExponentialHistogramCollector(1, 1, 3) })
+}
@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JoiningCollector joins the elements of the stream into a string, formatting
+// each with %v and putting delimiter between them, prefix before them and
+// suffix after them. Unlike concatenating with streams.Reduce, it takes time
+// in proportion to the length of the result.
+type JoiningCollector struct {
+	delimiter string
+	prefix    string
+	suffix    string
+	joined    strings.Builder
+	empty     bool
+}
+
+// NewJoiningCollector creates an empty JoiningCollector and returns a pointer to it.
+func NewJoiningCollector(delimiter, prefix, suffix string) *JoiningCollector {
+	collector := JoiningCollector{delimiter: delimiter, prefix: prefix, suffix: suffix, empty: true}
+	return &collector
+}
+
+// Add appends the element, after the delimiter unless it is the first
+func (collector *JoiningCollector) Add(element interface{}) {
+	if !collector.empty {
+		collector.joined.WriteString(collector.delimiter)
+	}
+	collector.empty = false
+	_, _ = fmt.Fprint(&collector.joined, element)
+}
+
+// Complete returns the joined elements between the prefix and suffix as a
+// string. For an empty stream, that is just the prefix and suffix.
+func (collector *JoiningCollector) Complete() interface{} {
+	return collector.prefix + collector.joined.String() + collector.suffix
+}
+
+// Supplier returns a new JoiningCollector with the same delimiter, prefix and suffix
+func (collector *JoiningCollector) Supplier() Collector {
+	return NewJoiningCollector(collector.delimiter, collector.prefix, collector.suffix)
+}
+
+// Combine appends the elements joined by other, which must be a
+// JoiningCollector. With ParallelCollect, the elements are joined
+// in no particular order.
+func (collector *JoiningCollector) Combine(other Collector) {
+	asJoining := other.(*JoiningCollector)
+	if asJoining.empty {
+		return
+	}
+	if !collector.empty {
+		collector.joined.WriteString(collector.delimiter)
+	}
+	collector.empty = false
+	collector.joined.WriteString(asJoining.joined.String())
+}
+
+// WriterCollector is JoiningCollector for output too large to hold in
+// memory: it writes the joined elements to an io.Writer as they arrive.
+// With streams.Collect, a failed write does not stop the stream: the rest of
+// the elements are read and discarded, and the error is only returned by
+// CompleteErr. To stop the stream at the first failed write, pass AddErr to
+// streams.ForEachErr instead, then call CompleteErr.
+type WriterCollector struct {
+	writer    io.Writer
+	delimiter string
+	prefix    string
+	suffix    string
+	started   bool
+	completed bool
+	written   int64
+	err       error
+}
+
+// NewWriterCollector creates a WriterCollector that writes to writer and
+// returns a pointer to it. Nothing is written until the first element, or
+// until the collector is completed for an empty stream.
+func NewWriterCollector(writer io.Writer, delimiter, prefix, suffix string) *WriterCollector {
+	collector := WriterCollector{writer: writer, delimiter: delimiter, prefix: prefix, suffix: suffix}
+	return &collector
+}
+
+// Add writes the element, after the prefix if it is the first
+// and after the delimiter if not
+func (collector *WriterCollector) Add(element interface{}) {
+	if collector.started {
+		collector.write(collector.delimiter)
+	} else {
+		collector.start()
+	}
+	collector.write(element)
+}
+
+// AddErr is Add, but it returns the first error from writing, if any. It
+// matches streams.ConsumerErr, so streams.ForEachErr stops the stream at the
+// first failed write.
+func (collector *WriterCollector) AddErr(element interface{}) error {
+	collector.Add(element)
+	return collector.err
+}
+
+func (collector *WriterCollector) start() {
+	collector.started = true
+	collector.write(collector.prefix)
+}
+
+// write formats and writes element with %v, unless an earlier write failed
+func (collector *WriterCollector) write(element interface{}) {
+	if collector.err != nil {
+		return
+	}
+	written, err := fmt.Fprint(collector.writer, element)
+	collector.written += int64(written)
+	collector.err = err
+}
+
+// Complete writes the suffix, the first time it is called, and returns
+// the number of bytes written as an int64
+func (collector *WriterCollector) Complete() interface{} {
+	written, _ := collector.CompleteErr()
+	return written
+}
+
+// CompleteErr writes the suffix, the first time it is called, and returns
+// the number of bytes written as an int64, along with the first error from
+// writing, if any. streams.CollectErr returns that error.
+func (collector *WriterCollector) CompleteErr() (interface{}, error) {
+	if !collector.completed {
+		if !collector.started {
+			collector.start()
+		}
+		collector.write(collector.suffix)
+		collector.completed = true
+	}
+	return collector.written, collector.err
+}
@@ -0,0 +1,114 @@
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+type JoiningCollectorCase struct {
+	Start     []interface{}
+	Delimiter string
+	Prefix    string
+	Suffix    string
+	Expected  string
+}
+
+var joiningCases = []JoiningCollectorCase{
+	{[]interface{}{}, ", ", "[", "]", "[]"},
+	{[]interface{}{"a"}, ", ", "[", "]", "[a]"},
+	{[]interface{}{1, "b", 2.5}, ", ", "[", "]", "[1, b, 2.5]"},
+	{[]interface{}{"a", "b"}, "", "", "", "ab"},
+}
+
+func TestJoiningCollector(t *testing.T) {
+	for _, caze := range joiningCases {
+		actual := streams.FromCollection(caze.Start).
+			Collect(NewJoiningCollector(caze.Delimiter, caze.Prefix, caze.Suffix))
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestJoiningCollector_ParallelCollect(t *testing.T) {
+	elements := []interface{}{"a", "b", "c", "d", "e"}
+
+	actual := streams.FromCollection(elements).
+		ParallelCollect(3, NewJoiningCollector(",", "<", ">")).(string)
+
+	assert.True(t, strings.HasPrefix(actual, "<"))
+	assert.True(t, strings.HasSuffix(actual, ">"))
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d", "e"}, strings.Split(actual[1:len(actual)-1], ","))
+}
+
+func TestWriterCollector(t *testing.T) {
+	for _, caze := range joiningCases {
+		var buffer bytes.Buffer
+		written, err := streams.FromCollection(caze.Start).
+			CollectErr(NewWriterCollector(&buffer, caze.Delimiter, caze.Prefix, caze.Suffix))
+
+		assert.Nil(t, err)
+		assert.Equal(t, caze.Expected, buffer.String())
+		assert.Equal(t, int64(len(caze.Expected)), written)
+	}
+}
+
+// limitedWriter fails once it has written limit bytes
+type limitedWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (writer *limitedWriter) Write(toWrite []byte) (int, error) {
+	if writer.Len()+len(toWrite) > writer.limit {
+		return 0, errors.New("disk full")
+	}
+	return writer.Buffer.Write(toWrite)
+}
+
+func TestWriterCollector_Err(t *testing.T) {
+	writer := limitedWriter{limit: 5}
+
+	written, err := streams.FromCollection([]interface{}{"ab", "cd", "ef"}).
+		CollectErr(NewWriterCollector(&writer, ",", "", ""))
+
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, int64(5), written)
+	assert.Equal(t, "ab,cd", writer.String())
+}
+
+func TestWriterCollector_AddErr(t *testing.T) {
+	writer := limitedWriter{limit: 5}
+	collector := NewWriterCollector(&writer, ",", "[", "]")
+	seen := 0
+
+	err := streams.FromCollection([]interface{}{"ab", "cd", "ef", "gh"}).
+		ForEachErr(func(element interface{}) error {
+			seen++
+			return collector.AddErr(element)
+		})
+	written, completeErr := collector.CompleteErr()
+
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, err, completeErr)
+	assert.Equal(t, 2, seen)
+	assert.Equal(t, int64(4), written)
+	assert.Equal(t, "[ab,", writer.String())
+}
+
+func TestWriterCollector_CompleteErrOnce(t *testing.T) {
+	var buffer bytes.Buffer
+	collector := NewWriterCollector(&buffer, ",", "[", "]")
+	collector.Add("a")
+
+	first, _ := collector.CompleteErr()
+	second, _ := collector.CompleteErr()
+
+	assert.Equal(t, "[a]", buffer.String())
+	assert.Equal(t, int64(3), first)
+	assert.Equal(t, first, second)
+}
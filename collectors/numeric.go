@@ -0,0 +1,228 @@
+package collectors
+
+import (
+	"fmt"
+	"math"
+)
+
+// toFloat converts an int, int64 or float64 element to float64
+func toFloat(element interface{}) float64 {
+	switch number := element.(type) {
+	case int:
+		return float64(number)
+	case int64:
+		return float64(number)
+	case float64:
+		return number
+	default:
+		panic(fmt.Sprintf("collectors: %T is not an int, int64 or float64", element))
+	}
+}
+
+// CountingCollector counts the elements of the stream
+type CountingCollector struct {
+	count int
+}
+
+// NewCountingCollector creates a CountingCollector with a count of 0
+// and returns a pointer to it.
+func NewCountingCollector() *CountingCollector {
+	collector := CountingCollector{}
+	return &collector
+}
+
+// Add counts the element
+func (collector *CountingCollector) Add(_ interface{}) {
+	collector.count++
+}
+
+// Complete returns the count as an int
+func (collector *CountingCollector) Complete() interface{} {
+	return collector.count
+}
+
+// Supplier returns a new CountingCollector
+func (collector *CountingCollector) Supplier() Collector {
+	return NewCountingCollector()
+}
+
+// Combine adds the count of other, which must be a CountingCollector
+func (collector *CountingCollector) Combine(other Collector) {
+	collector.count += other.(*CountingCollector).count
+}
+
+// SummingCollector sums int, int64 and float64 elements
+type SummingCollector struct {
+	intSum   int64
+	floatSum float64
+	sawInt64 bool
+	sawFloat bool
+}
+
+// NewSummingCollector creates a SummingCollector with a sum of 0
+// and returns a pointer to it.
+func NewSummingCollector() *SummingCollector {
+	collector := SummingCollector{}
+	return &collector
+}
+
+// Add adds the element, which must be an int, int64 or float64, to the sum.
+// Integers are summed separately from floats so they do not lose precision.
+func (collector *SummingCollector) Add(element interface{}) {
+	switch number := element.(type) {
+	case int:
+		collector.intSum += int64(number)
+	case int64:
+		collector.intSum += number
+		collector.sawInt64 = true
+	default:
+		collector.floatSum += toFloat(element)
+		collector.sawFloat = true
+	}
+}
+
+// Complete returns the sum. It is a float64 if any element was a float64,
+// otherwise an int64 if any element was an int64, otherwise an int.
+func (collector *SummingCollector) Complete() interface{} {
+	switch {
+	case collector.sawFloat:
+		return float64(collector.intSum) + collector.floatSum
+	case collector.sawInt64:
+		return collector.intSum
+	default:
+		return int(collector.intSum)
+	}
+}
+
+// Supplier returns a new SummingCollector
+func (collector *SummingCollector) Supplier() Collector {
+	return NewSummingCollector()
+}
+
+// Combine adds the sum of other, which must be a SummingCollector
+func (collector *SummingCollector) Combine(other Collector) {
+	asSumming := other.(*SummingCollector)
+	collector.intSum += asSumming.intSum
+	collector.floatSum += asSumming.floatSum
+	collector.sawInt64 = collector.sawInt64 || asSumming.sawInt64
+	collector.sawFloat = collector.sawFloat || asSumming.sawFloat
+}
+
+// AveragingCollector averages int, int64 and float64 elements
+type AveragingCollector struct {
+	count int
+	sum   float64
+}
+
+// NewAveragingCollector creates an empty AveragingCollector
+// and returns a pointer to it.
+func NewAveragingCollector() *AveragingCollector {
+	collector := AveragingCollector{}
+	return &collector
+}
+
+// Add adds the element, which must be an int, int64 or float64, to the average
+func (collector *AveragingCollector) Add(element interface{}) {
+	collector.count++
+	collector.sum += toFloat(element)
+}
+
+// Complete returns the average as a float64, or 0.0 for an empty stream
+func (collector *AveragingCollector) Complete() interface{} {
+	if collector.count == 0 {
+		return 0.0
+	}
+	return collector.sum / float64(collector.count)
+}
+
+// Supplier returns a new AveragingCollector
+func (collector *AveragingCollector) Supplier() Collector {
+	return NewAveragingCollector()
+}
+
+// Combine adds the elements averaged by other, which must be an AveragingCollector
+func (collector *AveragingCollector) Combine(other Collector) {
+	asAveraging := other.(*AveragingCollector)
+	collector.count += asAveraging.count
+	collector.sum += asAveraging.sum
+}
+
+// Statistics is the result of StatisticsCollector. Variance is the population
+// variance. Every field is 0 for an empty stream.
+type Statistics struct {
+	Count    int
+	Min      float64
+	Max      float64
+	Sum      float64
+	Mean     float64
+	Variance float64
+}
+
+// StatisticsCollector computes the Statistics of int, int64 and float64
+// elements in a single pass, without keeping the elements.
+type StatisticsCollector struct {
+	statistics Statistics
+	// sum of squared differences from the mean, as in Welford's algorithm
+	squares float64
+}
+
+// NewStatisticsCollector creates an empty StatisticsCollector
+// and returns a pointer to it.
+func NewStatisticsCollector() *StatisticsCollector {
+	collector := StatisticsCollector{}
+	return &collector
+}
+
+// Add adds the element, which must be an int, int64 or float64, to the statistics
+func (collector *StatisticsCollector) Add(element interface{}) {
+	value := toFloat(element)
+	statistics := &collector.statistics
+	if statistics.Count == 0 {
+		statistics.Min, statistics.Max = value, value
+	} else {
+		statistics.Min = math.Min(statistics.Min, value)
+		statistics.Max = math.Max(statistics.Max, value)
+	}
+	statistics.Count++
+	statistics.Sum += value
+	delta := value - statistics.Mean
+	statistics.Mean += delta / float64(statistics.Count)
+	collector.squares += delta * (value - statistics.Mean)
+}
+
+// Complete returns the Statistics of the elements
+func (collector *StatisticsCollector) Complete() interface{} {
+	statistics := collector.statistics
+	if statistics.Count > 0 {
+		statistics.Variance = collector.squares / float64(statistics.Count)
+	}
+	return statistics
+}
+
+// Supplier returns a new StatisticsCollector
+func (collector *StatisticsCollector) Supplier() Collector {
+	return NewStatisticsCollector()
+}
+
+// Combine adds the elements of other, which must be a StatisticsCollector,
+// using Chan et al.'s method of combining variances.
+func (collector *StatisticsCollector) Combine(other Collector) {
+	asStatistics := other.(*StatisticsCollector)
+	mine, theirs := &collector.statistics, asStatistics.statistics
+	if theirs.Count == 0 {
+		return
+	}
+	if mine.Count == 0 {
+		*collector = *asStatistics
+		return
+	}
+
+	count := float64(mine.Count + theirs.Count)
+	delta := theirs.Mean - mine.Mean
+	collector.squares += asStatistics.squares + delta*delta*float64(mine.Count)*float64(theirs.Count)/count
+	mine.Mean += delta * float64(theirs.Count) / count
+	mine.Count += theirs.Count
+	mine.Sum += theirs.Sum
+	mine.Min = math.Min(mine.Min, theirs.Min)
+	mine.Max = math.Max(mine.Max, theirs.Max)
+}
@@ -0,0 +1,91 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+type NumericCollectorCase struct {
+	Start    []interface{}
+	Expected interface{}
+}
+
+func TestCountingCollector(t *testing.T) {
+	cases := []NumericCollectorCase{
+		{[]interface{}{}, 0},
+		{[]interface{}{"a", nil, 3}, 3},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewCountingCollector())
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(2, NewCountingCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestSummingCollector(t *testing.T) {
+	cases := []NumericCollectorCase{
+		{[]interface{}{}, 0},
+		{[]interface{}{1, 2, 3}, 6},
+		{[]interface{}{1, int64(2), 3}, int64(6)},
+		{[]interface{}{1, int64(2), 0.5}, 3.5},
+		{[]interface{}{0.25, 0.5}, 0.75},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewSummingCollector())
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(2, NewSummingCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestSummingCollectorPanicsOnStrings(t *testing.T) {
+	assert.Panics(t, func() { NewSummingCollector().Add("1") })
+}
+
+func TestAveragingCollector(t *testing.T) {
+	cases := []NumericCollectorCase{
+		{[]interface{}{}, 0.0},
+		{[]interface{}{1, 2}, 1.5},
+		{[]interface{}{1, int64(2), 6.0}, 3.0},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewAveragingCollector())
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(2, NewAveragingCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestStatisticsCollector(t *testing.T) {
+	cases := []NumericCollectorCase{
+		{[]interface{}{}, Statistics{}},
+		{[]interface{}{3}, Statistics{Count: 1, Min: 3, Max: 3, Sum: 3, Mean: 3, Variance: 0}},
+		{
+			[]interface{}{2, int64(4), 4, 4, 5.0, 5, 7, 9},
+			Statistics{Count: 8, Min: 2, Max: 9, Sum: 40, Mean: 5, Variance: 4},
+		},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewStatisticsCollector())
+		parallel := streams.FromCollection(caze.Start).
+			ParallelCollect(3, NewStatisticsCollector()).(Statistics)
+
+		assert.Equal(t, caze.Expected, actual)
+		expected := caze.Expected.(Statistics)
+		assert.Equal(t, expected.Count, parallel.Count)
+		assert.Equal(t, expected.Min, parallel.Min)
+		assert.Equal(t, expected.Max, parallel.Max)
+		assert.InDelta(t, expected.Mean, parallel.Mean, 1e-9)
+		assert.InDelta(t, expected.Variance, parallel.Variance, 1e-9)
+	}
+}
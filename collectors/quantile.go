@@ -0,0 +1,212 @@
+package collectors
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCompression is the compression of a QuantileCollector created with
+// NewQuantileCollector. It keeps quantiles near the median within about 1%
+// of the stream, and quantiles near 0 and 1 much closer than that.
+const DefaultCompression = 100
+
+// centroid is a group of nearby elements, summarised by their mean and count
+type centroid struct {
+	mean  float64
+	count float64
+}
+
+// QuantileCollector estimates the quantiles of int, int64 and float64
+// elements using a merging t-digest, without keeping the elements. It uses
+// memory in proportion to its compression, not the size of the stream.
+type QuantileCollector struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// NewQuantileCollector creates an empty QuantileCollector with the
+// DefaultCompression and returns a pointer to it.
+func NewQuantileCollector() *QuantileCollector {
+	return NewQuantileCollectorWithCompression(DefaultCompression)
+}
+
+// NewQuantileCollectorWithCompression creates an empty QuantileCollector and
+// returns a pointer to it. A larger compression is more accurate, but keeps
+// more centroids: at most about compression of them.
+func NewQuantileCollectorWithCompression(compression float64) *QuantileCollector {
+	if compression <= 0 {
+		panic("collectors: non-positive compression")
+	}
+	collector := QuantileCollector{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+	return &collector
+}
+
+// Add adds the element, which must be an int, int64 or float64, to the digest
+func (collector *QuantileCollector) Add(element interface{}) {
+	value := toFloat(element)
+	collector.buffer = append(collector.buffer, centroid{value, 1})
+	collector.count++
+	collector.min = math.Min(collector.min, value)
+	collector.max = math.Max(collector.max, value)
+	if len(collector.buffer) >= 5*int(collector.compression) {
+		collector.compress()
+	}
+}
+
+// Complete returns the Quantiles of the elements
+func (collector *QuantileCollector) Complete() interface{} {
+	collector.compress()
+	return Quantiles{
+		centroids: append([]centroid{}, collector.centroids...),
+		count:     collector.count,
+		min:       collector.min,
+		max:       collector.max,
+	}
+}
+
+// Supplier returns a new QuantileCollector with the same compression
+func (collector *QuantileCollector) Supplier() Collector {
+	return NewQuantileCollectorWithCompression(collector.compression)
+}
+
+// Combine adds the digest of other, which must be a QuantileCollector
+func (collector *QuantileCollector) Combine(other Collector) {
+	asQuantile := other.(*QuantileCollector)
+	collector.buffer = append(collector.buffer, asQuantile.centroids...)
+	collector.buffer = append(collector.buffer, asQuantile.buffer...)
+	collector.count += asQuantile.count
+	collector.min = math.Min(collector.min, asQuantile.min)
+	collector.max = math.Max(collector.max, asQuantile.max)
+	collector.compress()
+}
+
+// compress merges the buffer into the centroids. Centroids near the median
+// may hold more elements than those near the ends, so that quantiles near
+// 0 and 1 stay accurate; the arcsine scale function decides how many more.
+func (collector *QuantileCollector) compress() {
+	if len(collector.buffer) == 0 {
+		return
+	}
+	all := append(collector.buffer, collector.centroids...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := []centroid{all[0]}
+	seen := 0.0
+	limit := collector.quantileLimit(0)
+	for _, next := range all[1:] {
+		current := &merged[len(merged)-1]
+		if (seen+current.count+next.count)/collector.count <= limit {
+			current.count += next.count
+			current.mean += (next.mean - current.mean) * next.count / current.count
+			continue
+		}
+		seen += current.count
+		limit = collector.quantileLimit(seen / collector.count)
+		merged = append(merged, next)
+	}
+
+	collector.centroids = merged
+	collector.buffer = nil
+}
+
+// quantileLimit returns the largest quantile that a centroid starting at
+// quantile q may reach, one step further along the scale function
+// k(q) = compression / 2π * asin(2q - 1)
+func (collector *QuantileCollector) quantileLimit(q float64) float64 {
+	scale := collector.compression / (2 * math.Pi)
+	k := scale*math.Asin(2*q-1) + 1
+	if k >= scale*math.Pi/2 {
+		return 1
+	}
+	return (math.Sin(k/scale) + 1) / 2
+}
+
+// Quantiles is the result of QuantileCollector
+type Quantiles struct {
+	centroids []centroid
+	count     float64
+	min       float64
+	max       float64
+}
+
+// Count returns the number of elements
+func (quantiles Quantiles) Count() int {
+	return int(quantiles.count)
+}
+
+// Min returns the smallest element, or NaN for an empty stream
+func (quantiles Quantiles) Min() float64 {
+	if quantiles.count == 0 {
+		return math.NaN()
+	}
+	return quantiles.min
+}
+
+// Max returns the largest element, or NaN for an empty stream
+func (quantiles Quantiles) Max() float64 {
+	if quantiles.count == 0 {
+		return math.NaN()
+	}
+	return quantiles.max
+}
+
+// Quantile estimates the value below which q of the elements fall, for q
+// between 0 and 1. Quantile(0.5) is the median. It returns NaN for an empty
+// stream or a q outside of [0, 1].
+func (quantiles Quantiles) Quantile(q float64) float64 {
+	if quantiles.count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	ranks, values := quantiles.points()
+	rank := q * quantiles.count
+	i := sort.SearchFloat64s(ranks, rank)
+	if i == 0 {
+		return values[0]
+	}
+	return interpolate(rank, ranks[i-1], ranks[i], values[i-1], values[i])
+}
+
+// CDF estimates the fraction of the elements that are at most x. It
+// returns NaN for an empty stream.
+func (quantiles Quantiles) CDF(x float64) float64 {
+	if quantiles.count == 0 {
+		return math.NaN()
+	}
+	ranks, values := quantiles.points()
+	i := sort.Search(len(values), func(i int) bool { return values[i] > x })
+	switch i {
+	case 0:
+		return 0
+	case len(values):
+		return 1
+	}
+	return interpolate(x, values[i-1], values[i], ranks[i-1], ranks[i]) / quantiles.count
+}
+
+// points returns the rank and value of the smallest element, the middle of
+// each centroid and the largest element, for interpolating between.
+func (quantiles Quantiles) points() ([]float64, []float64) {
+	ranks := []float64{0}
+	values := []float64{quantiles.min}
+	seen := 0.0
+	for _, centroid := range quantiles.centroids {
+		ranks = append(ranks, seen+centroid.count/2)
+		values = append(values, centroid.mean)
+		seen += centroid.count
+	}
+	ranks = append(ranks, quantiles.count)
+	values = append(values, quantiles.max)
+	return ranks, values
+}
+
+// interpolate returns the y on the line from (x0, y0) to (x1, y1) at x
+func interpolate(x, x0, x1, y0, y1 float64) float64 {
+	if x1 == x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
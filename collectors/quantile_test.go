@@ -0,0 +1,85 @@
+package collectors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+// shuffledRange returns 1 to n in a random but repeatable order
+func shuffledRange(n int) []interface{} {
+	elements := make([]interface{}, n)
+	for i, value := range rand.New(rand.NewSource(1)).Perm(n) {
+		elements[i] = value + 1
+	}
+	return elements
+}
+
+func TestQuantileCollector(t *testing.T) {
+	elements := shuffledRange(10000)
+
+	actual := streams.FromCollection(elements).Collect(NewQuantileCollector()).(Quantiles)
+	parallel := streams.FromCollection(elements).ParallelCollect(4, NewQuantileCollector()).(Quantiles)
+
+	for _, quantiles := range []Quantiles{actual, parallel} {
+		assert.Equal(t, 10000, quantiles.Count())
+		assert.Equal(t, 1.0, quantiles.Min())
+		assert.Equal(t, 10000.0, quantiles.Max())
+		assert.Equal(t, 1.0, quantiles.Quantile(0))
+		assert.Equal(t, 10000.0, quantiles.Quantile(1))
+		assert.InDelta(t, 5000, quantiles.Quantile(0.5), 100)
+		assert.InDelta(t, 9900, quantiles.Quantile(0.99), 20)
+		assert.InDelta(t, 10, quantiles.Quantile(0.001), 5)
+		assert.InDelta(t, 0.25, quantiles.CDF(2500), 0.01)
+		assert.Equal(t, 0.0, quantiles.CDF(0))
+		assert.Equal(t, 1.0, quantiles.CDF(10000))
+	}
+}
+
+func TestQuantileCollector_Compression(t *testing.T) {
+	elements := shuffledRange(10000)
+
+	coarse := streams.FromCollection(elements).Collect(NewQuantileCollectorWithCompression(20)).(Quantiles)
+	fine := streams.FromCollection(elements).Collect(NewQuantileCollectorWithCompression(500)).(Quantiles)
+
+	assert.LessOrEqual(t, len(coarse.centroids), 20)
+	assert.Less(t, len(coarse.centroids), len(fine.centroids))
+	assert.InDelta(t, 9000, fine.Quantile(0.9), 10)
+}
+
+func TestQuantileCollector_Small(t *testing.T) {
+	quantiles := streams.FromCollection([]interface{}{3, 1.5, int64(2)}).
+		Collect(NewQuantileCollector()).(Quantiles)
+
+	assert.Equal(t, 2.0, quantiles.Quantile(0.5))
+	assert.Equal(t, 1.5, quantiles.Quantile(0))
+	assert.Equal(t, 3.0, quantiles.Quantile(1))
+	assert.True(t, math.IsNaN(quantiles.Quantile(2)))
+	assert.Equal(t, 0.0, quantiles.CDF(1))
+	assert.Equal(t, 1.0, quantiles.CDF(3))
+}
+
+func TestQuantileCollector_Repeated(t *testing.T) {
+	quantiles := streams.FromCollection([]interface{}{5, 5, 5, 5}).
+		Collect(NewQuantileCollector()).(Quantiles)
+
+	assert.Equal(t, 5.0, quantiles.Quantile(0.3))
+	assert.Equal(t, 0.0, quantiles.CDF(4.9))
+	assert.Equal(t, 1.0, quantiles.CDF(5))
+}
+
+func TestQuantileCollector_Empty(t *testing.T) {
+	quantiles := streams.FromCollection([]interface{}{}).Collect(NewQuantileCollector()).(Quantiles)
+
+	assert.Equal(t, 0, quantiles.Count())
+	assert.True(t, math.IsNaN(quantiles.Min()))
+	assert.True(t, math.IsNaN(quantiles.Quantile(0.5)))
+	assert.True(t, math.IsNaN(quantiles.CDF(1)))
+}
+
+func TestQuantileCollectorPanicsOnNonPositiveCompression(t *testing.T) {
+	assert.Panics(t, func() { NewQuantileCollectorWithCompression(0) })
+}
@@ -0,0 +1,160 @@
+package collectors
+
+import (
+	"math/rand"
+)
+
+// reservoir is a uniform random sample of up to n of the elements seen,
+// kept with Algorithm R
+type reservoir struct {
+	n      int
+	seen   int
+	sample []interface{}
+}
+
+func (reservoir *reservoir) add(element interface{}, random *rand.Rand) {
+	reservoir.seen++
+	if len(reservoir.sample) < reservoir.n {
+		reservoir.sample = append(reservoir.sample, element)
+	} else if i := random.Intn(reservoir.seen); i < reservoir.n {
+		reservoir.sample[i] = element
+	}
+}
+
+// combine replaces the sample with a uniform random sample of the elements
+// seen by both reservoirs. Each element of a sample stands in for the same
+// number of elements seen, so the number of elements to take from each
+// sample is drawn as if the elements seen were drawn without replacement.
+func (reservoir *reservoir) combine(other *reservoir, random *rand.Rand) {
+	total := reservoir.seen + other.seen
+	size := reservoir.n
+	if total < size {
+		size = total
+	}
+	mine, theirs := reservoir.seen, other.seen
+	fromMine := 0
+	for i := 0; i < size; i++ {
+		if random.Intn(mine+theirs) < mine {
+			fromMine++
+			mine--
+		} else {
+			theirs--
+		}
+	}
+
+	combined := append(pick(reservoir.sample, fromMine, random), pick(other.sample, size-fromMine, random)...)
+	reservoir.sample = combined
+	reservoir.seen = total
+}
+
+// pick returns count elements of sample, chosen at random
+func pick(sample []interface{}, count int, random *rand.Rand) []interface{} {
+	picked := append([]interface{}{}, sample...)
+	for i := 0; i < count; i++ {
+		j := i + random.Intn(len(picked)-i)
+		picked[i], picked[j] = picked[j], picked[i]
+	}
+	return picked[:count]
+}
+
+// ReservoirSampleCollector collects a uniform random sample of n elements of
+// the stream, or every element if there are fewer, keeping at most n at a
+// time. Collecting the same stream with the same seed gives the same sample;
+// ParallelCollect does not, since the elements reach each worker in no
+// particular order.
+type ReservoirSampleCollector struct {
+	reservoir reservoir
+	random    *rand.Rand
+}
+
+// NewReservoirSampleCollector creates an empty ReservoirSampleCollector and
+// returns a pointer to it.
+func NewReservoirSampleCollector(n int, seed int64) *ReservoirSampleCollector {
+	if n <= 0 {
+		panic("collectors: non-positive sample size")
+	}
+	collector := ReservoirSampleCollector{reservoir{n: n}, rand.New(rand.NewSource(seed))}
+	return &collector
+}
+
+// Add adds the element to the sample with a probability of n over the
+// number of elements seen
+func (collector *ReservoirSampleCollector) Add(element interface{}) {
+	collector.reservoir.add(element, collector.random)
+}
+
+// Complete returns the sample as a []interface{}, in no particular order
+func (collector *ReservoirSampleCollector) Complete() interface{} {
+	return append([]interface{}{}, collector.reservoir.sample...)
+}
+
+// Supplier returns a new ReservoirSampleCollector with the same n, seeded
+// from this collector so that each partial sample is independent
+func (collector *ReservoirSampleCollector) Supplier() Collector {
+	return NewReservoirSampleCollector(collector.reservoir.n, collector.random.Int63())
+}
+
+// Combine replaces the sample with a sample of the elements seen by the
+// collector and other, which must be a ReservoirSampleCollector
+func (collector *ReservoirSampleCollector) Combine(other Collector) {
+	collector.reservoir.combine(&other.(*ReservoirSampleCollector).reservoir, collector.random)
+}
+
+// StratifiedSampleCollector collects a ReservoirSampleCollector style sample
+// of n values for each key, so that rare keys are as well represented as
+// common ones.
+type StratifiedSampleCollector struct {
+	toEntry    func(element interface{}) (interface{}, interface{})
+	reservoirs map[interface{}]*reservoir
+	n          int
+	random     *rand.Rand
+}
+
+// NewStratifiedSampleCollector creates an empty StratifiedSampleCollector and
+// returns a pointer to it. toEntry gets the key and value from an element,
+// like a mappers.EntryCreator, which can be passed as is.
+func NewStratifiedSampleCollector(toEntry func(element interface{}) (interface{}, interface{}), n int, seed int64) *StratifiedSampleCollector {
+	if n <= 0 {
+		panic("collectors: non-positive sample size")
+	}
+	collector := StratifiedSampleCollector{toEntry, map[interface{}]*reservoir{}, n, rand.New(rand.NewSource(seed))}
+	return &collector
+}
+
+// Add adds the value of the element to the sample of its key
+func (collector *StratifiedSampleCollector) Add(element interface{}) {
+	key, value := collector.toEntry(element)
+	collector.stratum(key).add(value, collector.random)
+}
+
+func (collector *StratifiedSampleCollector) stratum(key interface{}) *reservoir {
+	stratum, ok := collector.reservoirs[key]
+	if !ok {
+		stratum = &reservoir{n: collector.n}
+		collector.reservoirs[key] = stratum
+	}
+	return stratum
+}
+
+// Complete returns a map[interface{}][]interface{} of each key to its sample
+func (collector *StratifiedSampleCollector) Complete() interface{} {
+	samples := map[interface{}][]interface{}{}
+	for key, stratum := range collector.reservoirs {
+		samples[key] = append([]interface{}{}, stratum.sample...)
+	}
+	return samples
+}
+
+// Supplier returns a new StratifiedSampleCollector with the same toEntry and
+// n, seeded from this collector so that each partial sample is independent
+func (collector *StratifiedSampleCollector) Supplier() Collector {
+	return NewStratifiedSampleCollector(collector.toEntry, collector.n, collector.random.Int63())
+}
+
+// Combine replaces the sample of each key with a sample of the elements seen
+// by the collector and other, which must be a StratifiedSampleCollector
+func (collector *StratifiedSampleCollector) Combine(other Collector) {
+	for key, stratum := range other.(*StratifiedSampleCollector).reservoirs {
+		collector.stratum(key).combine(stratum, collector.random)
+	}
+}
@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservoirSampleCollector(t *testing.T) {
+	elements := shuffledRange(1000)
+
+	first := streams.FromCollection(elements).Collect(NewReservoirSampleCollector(10, 42)).([]interface{})
+	second := streams.FromCollection(elements).Collect(NewReservoirSampleCollector(10, 42)).([]interface{})
+	other := streams.FromCollection(elements).Collect(NewReservoirSampleCollector(10, 7)).([]interface{})
+	parallel := streams.FromCollection(elements).
+		ParallelCollect(3, NewReservoirSampleCollector(10, 42)).([]interface{})
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	for _, sample := range [][]interface{}{first, other, parallel} {
+		assert.Len(t, sample, 10)
+		assert.Subset(t, elements, sample)
+		assert.Equal(t, sortedInts(sample), sortedInts(distinct(sample)))
+	}
+}
+
+func TestReservoirSampleCollector_Small(t *testing.T) {
+	elements := []interface{}{3, 1, 2}
+
+	actual := streams.FromCollection(elements).Collect(NewReservoirSampleCollector(5, 1))
+	parallel := streams.FromCollection(elements).ParallelCollect(2, NewReservoirSampleCollector(5, 1))
+
+	assert.Equal(t, elements, actual)
+	assert.Equal(t, []interface{}{1, 2, 3}, sortedInts(parallel.([]interface{})))
+}
+
+// TestReservoirSampleCollector_Uniform checks that every element is about
+// as likely to be sampled, including when partial samples are combined
+func TestReservoirSampleCollector_Uniform(t *testing.T) {
+	elements := shuffledRange(20)
+	counts := map[interface{}]int{}
+	for seed := int64(0); seed < 2000; seed++ {
+		sequential := NewReservoirSampleCollector(5, seed)
+		first := sequential.Supplier().(*ReservoirSampleCollector)
+		second := sequential.Supplier()
+		for i, element := range elements {
+			sequential.Add(element)
+			// uneven partials, so the combine has to weigh them
+			if i < 5 {
+				first.Add(element)
+			} else {
+				second.Add(element)
+			}
+		}
+		first.Combine(second)
+		for _, element := range sequential.Complete().([]interface{}) {
+			counts[element]++
+		}
+		for _, element := range first.Complete().([]interface{}) {
+			counts[element]++
+		}
+	}
+
+	// each of the 20 elements is expected 2 * 2000 * 5 / 20 = 1000 times
+	for _, element := range elements {
+		assert.InDelta(t, 1000, counts[element], 150)
+	}
+}
+
+func toKeyValue(element interface{}) (interface{}, interface{}) {
+	split := strings.Split(element.(string), ":")
+	return split[0], split[1]
+}
+
+func TestStratifiedSampleCollector(t *testing.T) {
+	elements := []interface{}{"rare:1"}
+	for i := 0; i < 100; i++ {
+		elements = append(elements, "common:x")
+	}
+	elements = append(elements, "rare:2", "rare:3")
+
+	actual := streams.FromCollection(elements).
+		Collect(NewStratifiedSampleCollector(toKeyValue, 2, 42)).(map[interface{}][]interface{})
+	again := streams.FromCollection(elements).
+		Collect(NewStratifiedSampleCollector(toKeyValue, 2, 42))
+	parallel := streams.FromCollection(elements).
+		ParallelCollect(3, NewStratifiedSampleCollector(toKeyValue, 2, 42)).(map[interface{}][]interface{})
+
+	assert.Equal(t, actual, again)
+	for _, samples := range []map[interface{}][]interface{}{actual, parallel} {
+		assert.Len(t, samples, 2)
+		assert.Equal(t, []interface{}{"x", "x"}, samples["common"])
+		assert.Len(t, samples["rare"], 2)
+		assert.Subset(t, []interface{}{"1", "2", "3"}, samples["rare"])
+	}
+}
+
+func TestSampleCollectorsPanicOnNonPositiveSize(t *testing.T) {
+	assert.Panics(t, func() { NewReservoirSampleCollector(0, 1) })
+	assert.Panics(t, func() { NewStratifiedSampleCollector(toKeyValue, 0, 1) })
+}
+
+func distinct(elements []interface{}) []interface{} {
+	seen := map[interface{}]bool{}
+	unique := []interface{}{}
+	for _, element := range elements {
+		if !seen[element] {
+			seen[element] = true
+			unique = append(unique, element)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,252 @@
+package collectors
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopKCollector collects the k largest elements of the stream according to
+// less, keeping at most k elements at a time.
+type TopKCollector struct {
+	k        int
+	elements boundedHeap
+}
+
+// NewTopKCollector creates an empty TopKCollector and returns a pointer to it.
+// For the k smallest elements, swap the arguments of less.
+func NewTopKCollector(k int, less func(first, second interface{}) bool) *TopKCollector {
+	if k <= 0 {
+		panic("collectors: non-positive k")
+	}
+	collector := TopKCollector{k, boundedHeap{less: less}}
+	return &collector
+}
+
+// Add adds the element if it is one of the k largest thus far
+func (collector *TopKCollector) Add(element interface{}) {
+	elements := &collector.elements
+	if elements.Len() < collector.k {
+		heap.Push(elements, element)
+	} else if elements.less(elements.elements[0], element) {
+		elements.elements[0] = element
+		heap.Fix(elements, 0)
+	}
+}
+
+// Complete returns the k largest elements as a []interface{}, largest first.
+// Of equal elements, which are kept is unspecified.
+func (collector *TopKCollector) Complete() interface{} {
+	sorted := append([]interface{}{}, collector.elements.elements...)
+	less := collector.elements.less
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[j], sorted[i]) })
+	return sorted
+}
+
+// Supplier returns a new TopKCollector with the same k and less
+func (collector *TopKCollector) Supplier() Collector {
+	return NewTopKCollector(collector.k, collector.elements.less)
+}
+
+// Combine adds the elements of other, which must be a TopKCollector
+func (collector *TopKCollector) Combine(other Collector) {
+	for _, element := range other.(*TopKCollector).elements.elements {
+		collector.Add(element)
+	}
+}
+
+// boundedHeap is a min heap of elements, so the smallest kept element is
+// the first to be replaced
+type boundedHeap struct {
+	elements []interface{}
+	less     func(first, second interface{}) bool
+}
+
+func (kept *boundedHeap) Len() int {
+	return len(kept.elements)
+}
+
+func (kept *boundedHeap) Less(i, j int) bool {
+	return kept.less(kept.elements[i], kept.elements[j])
+}
+
+func (kept *boundedHeap) Swap(i, j int) {
+	kept.elements[i], kept.elements[j] = kept.elements[j], kept.elements[i]
+}
+
+func (kept *boundedHeap) Push(element interface{}) {
+	kept.elements = append(kept.elements, element)
+}
+
+func (kept *boundedHeap) Pop() interface{} {
+	last := kept.elements[len(kept.elements)-1]
+	kept.elements = kept.elements[:len(kept.elements)-1]
+	return last
+}
+
+// HeavyHitter is a key reported by HeavyHittersCollector. Count is at least
+// the number of times Key was seen and at most Error more than it.
+type HeavyHitter struct {
+	Key   interface{}
+	Count int
+	Error int
+}
+
+// HeavyHittersCollector estimates the k most frequent elements of the
+// stream with the Space-Saving algorithm. It counts 10 * k elements at a
+// time, so it finds every element seen more than 1 / (10 * k) of the time.
+// Elements must be usable as map keys.
+type HeavyHittersCollector struct {
+	k        int
+	counters counterHeap
+	keys     map[interface{}]*counter
+}
+
+// NewHeavyHittersCollector creates an empty HeavyHittersCollector and
+// returns a pointer to it.
+func NewHeavyHittersCollector(k int) *HeavyHittersCollector {
+	if k <= 0 {
+		panic("collectors: non-positive k")
+	}
+	collector := HeavyHittersCollector{k, counterHeap{}, map[interface{}]*counter{}}
+	return &collector
+}
+
+func (collector *HeavyHittersCollector) capacity() int {
+	return 10 * collector.k
+}
+
+// Add counts the element. When all counters are in use, the element takes
+// the counter of the least frequent element, and its count thus far becomes
+// the element's error.
+func (collector *HeavyHittersCollector) Add(element interface{}) {
+	collector.count(element, 1, 0)
+}
+
+func (collector *HeavyHittersCollector) count(key interface{}, count, overcount int) {
+	if existing, ok := collector.keys[key]; ok {
+		existing.Count += count
+		existing.Error += overcount
+		heap.Fix(&collector.counters, existing.index)
+		return
+	}
+	if len(collector.counters) < collector.capacity() {
+		added := &counter{HeavyHitter: HeavyHitter{key, count, overcount}}
+		collector.keys[key] = added
+		heap.Push(&collector.counters, added)
+		return
+	}
+	least := collector.counters[0]
+	delete(collector.keys, least.Key)
+	least.Error = least.Count + overcount
+	least.Key, least.Count = key, least.Count+count
+	collector.keys[key] = least
+	heap.Fix(&collector.counters, 0)
+}
+
+// Complete returns up to k HeavyHitters as a []HeavyHitter, most frequent first
+func (collector *HeavyHittersCollector) Complete() interface{} {
+	hitters := make([]HeavyHitter, 0, len(collector.counters))
+	for _, counter := range collector.counters {
+		hitters = append(hitters, counter.HeavyHitter)
+	}
+	sort.SliceStable(hitters, func(i, j int) bool { return hitters[i].Count > hitters[j].Count })
+	if len(hitters) > collector.k {
+		hitters = hitters[:collector.k]
+	}
+	return hitters
+}
+
+// Supplier returns a new HeavyHittersCollector with the same k
+func (collector *HeavyHittersCollector) Supplier() Collector {
+	return NewHeavyHittersCollector(collector.k)
+}
+
+// Combine adds the counts of other, which must be a HeavyHittersCollector.
+// A key counted by only one of them may have been seen by the other as often
+// as the other's least frequent key, so that count is added to its error.
+func (collector *HeavyHittersCollector) Combine(other Collector) {
+	asHeavy := other.(*HeavyHittersCollector)
+	mine, theirs := collector.leastCount(), asHeavy.leastCount()
+
+	combined := map[interface{}]HeavyHitter{}
+	for key, counter := range collector.keys {
+		hitter := counter.HeavyHitter
+		if _, ok := asHeavy.keys[key]; !ok {
+			hitter.Count += theirs
+			hitter.Error += theirs
+		}
+		combined[key] = hitter
+	}
+	for key, counter := range asHeavy.keys {
+		hitter, ok := combined[key]
+		if ok {
+			hitter.Count += counter.Count
+			hitter.Error += counter.Error
+		} else {
+			hitter = counter.HeavyHitter
+			hitter.Count += mine
+			hitter.Error += mine
+		}
+		combined[key] = hitter
+	}
+
+	hitters := make([]HeavyHitter, 0, len(combined))
+	for _, hitter := range combined {
+		hitters = append(hitters, hitter)
+	}
+	sort.Slice(hitters, func(i, j int) bool { return hitters[i].Count > hitters[j].Count })
+	if len(hitters) > collector.capacity() {
+		hitters = hitters[:collector.capacity()]
+	}
+
+	collector.counters = counterHeap{}
+	collector.keys = map[interface{}]*counter{}
+	for _, hitter := range hitters {
+		collector.count(hitter.Key, hitter.Count, hitter.Error)
+	}
+}
+
+// leastCount returns the count of the least frequent key if every counter is
+// in use, since any key without a counter may have been seen that often, or
+// 0 if not.
+func (collector *HeavyHittersCollector) leastCount() int {
+	if len(collector.counters) < collector.capacity() {
+		return 0
+	}
+	return collector.counters[0].Count
+}
+
+// counter is a HeavyHitter and its place in a counterHeap
+type counter struct {
+	HeavyHitter
+	index int
+}
+
+// counterHeap is a min heap of counters by count
+type counterHeap []*counter
+
+func (counters counterHeap) Len() int {
+	return len(counters)
+}
+
+func (counters counterHeap) Less(i, j int) bool {
+	return counters[i].Count < counters[j].Count
+}
+
+func (counters counterHeap) Swap(i, j int) {
+	counters[i], counters[j] = counters[j], counters[i]
+	counters[i].index, counters[j].index = i, j
+}
+
+func (counters *counterHeap) Push(element interface{}) {
+	added := element.(*counter)
+	added.index = len(*counters)
+	*counters = append(*counters, added)
+}
+
+func (counters *counterHeap) Pop() interface{} {
+	old := *counters
+	last := old[len(old)-1]
+	*counters = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,88 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(first, second interface{}) bool {
+	return first.(int) < second.(int)
+}
+
+type TopKCollectorCase struct {
+	Start    []interface{}
+	K        int
+	Expected []interface{}
+}
+
+func TestTopKCollector(t *testing.T) {
+	cases := []TopKCollectorCase{
+		{[]interface{}{}, 3, []interface{}{}},
+		{[]interface{}{2, 1}, 3, []interface{}{2, 1}},
+		{[]interface{}{5, 1, 9, 3, 7, 9, 2}, 3, []interface{}{9, 9, 7}},
+		{shuffledRange(1000), 4, []interface{}{1000, 999, 998, 997}},
+	}
+
+	for _, caze := range cases {
+		actual := streams.FromCollection(caze.Start).Collect(NewTopKCollector(caze.K, intLess))
+		parallel := streams.FromCollection(caze.Start).ParallelCollect(3, NewTopKCollector(caze.K, intLess))
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, parallel)
+	}
+}
+
+func TestTopKCollector_Smallest(t *testing.T) {
+	greater := func(first, second interface{}) bool { return intLess(second, first) }
+
+	actual := streams.FromCollection(shuffledRange(100)).Collect(NewTopKCollector(3, greater))
+
+	assert.Equal(t, []interface{}{1, 2, 3}, actual)
+}
+
+// zipfish returns a stream where key i appears 1000 / i times, shuffled
+func zipfish(keys int) []interface{} {
+	elements := []interface{}{}
+	for key := 1; key <= keys; key++ {
+		for i := 0; i < 1000/key; i++ {
+			elements = append(elements, key)
+		}
+	}
+	shuffled := make([]interface{}, len(elements))
+	for i, j := range shuffledRange(len(elements)) {
+		shuffled[i] = elements[j.(int)-1]
+	}
+	return shuffled
+}
+
+func TestHeavyHittersCollector(t *testing.T) {
+	elements := zipfish(500)
+
+	actual := streams.FromCollection(elements).Collect(NewHeavyHittersCollector(3)).([]HeavyHitter)
+	parallel := streams.FromCollection(elements).ParallelCollect(4, NewHeavyHittersCollector(3)).([]HeavyHitter)
+
+	for _, hitters := range [][]HeavyHitter{actual, parallel} {
+		assert.Len(t, hitters, 3)
+		for i, hitter := range hitters {
+			key := i + 1
+			assert.Equal(t, key, hitter.Key)
+			assert.GreaterOrEqual(t, hitter.Count, 1000/key)
+			assert.LessOrEqual(t, hitter.Count-hitter.Error, 1000/key)
+		}
+	}
+}
+
+func TestHeavyHittersCollector_Exact(t *testing.T) {
+	elements := []interface{}{"a", "b", "a", "c", "a", "b"}
+
+	actual := streams.FromCollection(elements).Collect(NewHeavyHittersCollector(2))
+
+	assert.Equal(t, []HeavyHitter{{"a", 3, 0}, {"b", 2, 0}}, actual)
+}
+
+func TestTopKCollectorsPanicOnNonPositiveK(t *testing.T) {
+	assert.Panics(t, func() { NewTopKCollector(0, intLess) })
+	assert.Panics(t, func() { NewHeavyHittersCollector(-1) })
+}
@@ -0,0 +1,130 @@
+package streams
+
+import (
+	"sync"
+)
+
+// Concat returns a new streams object with the elements of streams followed
+// by the elements of each of others, in order. The new streams object uses
+// the largest channelBuffer of its inputs, and the context of streams.
+// Once the new streams stop, so do their inputs, and the first error from
+// any input is reported by the new streams; see Err.
+func (streams *Streams) Concat(others ...*Streams) *Streams {
+	return streams.combine(others, func(life lifetime, inputs []Stream, next Stream, failed func(input Stream) bool) error {
+		for _, input := range inputs {
+			for {
+				element, ok := life.receive(input)
+				if !ok {
+					break
+				}
+				if !life.send(next, element) {
+					return nil
+				}
+			}
+			if failed(input) || life.stopped() {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// Merge returns a new streams object with the elements of streams and others,
+// in whatever order they become available. See Concat for how the new streams
+// object is set up.
+func (streams *Streams) Merge(others ...*Streams) *Streams {
+	return streams.combine(others, func(life lifetime, inputs []Stream, next Stream, failed func(input Stream) bool) error {
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(len(inputs))
+		for _, input := range inputs {
+			go func(input Stream) {
+				defer waitGroup.Done()
+				for {
+					element, ok := life.receive(input)
+					if !ok {
+						failed(input)
+						return
+					}
+					if !life.send(next, element) {
+						return
+					}
+				}
+			}(input)
+		}
+		waitGroup.Wait()
+		return nil
+	})
+}
+
+// Interleave returns a new streams object that takes one element from streams,
+// then one from each of others in turn, and so on. Inputs that run out are
+// skipped. See Concat for how the new streams object is set up.
+func (streams *Streams) Interleave(others ...*Streams) *Streams {
+	return streams.combine(others, func(life lifetime, inputs []Stream, next Stream, failed func(input Stream) bool) error {
+		for len(inputs) > 0 {
+			remaining := inputs[:0]
+			for _, input := range inputs {
+				element, ok := life.receive(input)
+				if !ok {
+					if failed(input) || life.stopped() {
+						return nil
+					}
+					continue
+				}
+				if !life.send(next, element) {
+					return nil
+				}
+				remaining = append(remaining, input)
+			}
+			inputs = remaining
+		}
+		return nil
+	})
+}
+
+// combine starts a new streams object fed by combiner, which reads from the
+// last Stream of streams and others. Whenever an input ends, combiner calls
+// failed with it, which returns true if the input stopped on an error. That
+// error has then already been passed on to the new streams, which halts them,
+// and combiner should return. Once combiner returns, every input is halted,
+// and the error combiner returned or the first error of an input, if any, is
+// passed on to the new streams.
+func (streams *Streams) combine(others []*Streams, combiner func(life lifetime, inputs []Stream, next Stream, failed func(input Stream) bool) error) *Streams {
+	all := append([]*Streams{streams}, others...)
+	inputs := make([]Stream, len(all))
+	byInput := make(map[Stream]*Streams, len(all))
+	bufferSize := 0
+	for index, input := range all {
+		inputs[index] = input.lastStream()
+		byInput[inputs[index]] = input
+		if input.channelBuffer > bufferSize {
+			bufferSize = input.channelBuffer
+		}
+	}
+
+	next := make(Stream, bufferSize)
+	combined := FromStream(next, bufferSize).WithContext(streams.ctx)
+	life := combined.lifetime()
+	failed := func(input Stream) bool {
+		err := byInput[input].firstFailure()
+		if err != nil {
+			combined.fail(err)
+		}
+		return err != nil
+	}
+
+	go func() {
+		defer close(next)
+		if err := combiner(life, inputs, next, failed); err != nil {
+			combined.fail(err)
+		}
+		for _, input := range all {
+			input.halt()
+			if err := input.firstFailure(); err != nil {
+				combined.fail(err)
+			}
+		}
+	}()
+
+	return combined
+}
@@ -0,0 +1,130 @@
+package streams
+
+import (
+	"bufio"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type CombineCase struct {
+	First    []interface{}
+	Others   [][]interface{}
+	Expected []interface{}
+}
+
+func fromCollections(collections [][]interface{}) []*Streams {
+	streams := make([]*Streams, len(collections))
+	for index, collection := range collections {
+		streams[index] = FromCollection(collection)
+	}
+	return streams
+}
+
+func TestStreams_Concat(t *testing.T) {
+	cases := []CombineCase{
+		{[]interface{}{}, nil, []interface{}{}},
+		{[]interface{}{1, 2}, nil, []interface{}{1, 2}},
+		{[]interface{}{1, 2}, [][]interface{}{{}, {3}, {4, 5}}, []interface{}{1, 2, 3, 4, 5}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.First).
+			Concat(fromCollections(caze.Others)...).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_Merge(t *testing.T) {
+	cases := []CombineCase{
+		{[]interface{}{}, nil, []interface{}{}},
+		{[]interface{}{1, 2}, [][]interface{}{{}, {3}, {4, 5}}, []interface{}{1, 2, 3, 4, 5}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.First).
+			Merge(fromCollections(caze.Others)...).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, sortedInts(actual))
+	}
+}
+
+func TestStreams_Interleave(t *testing.T) {
+	cases := []CombineCase{
+		{[]interface{}{}, nil, []interface{}{}},
+		{[]interface{}{1, 2, 3}, [][]interface{}{{"a", "b"}}, []interface{}{1, "a", 2, "b", 3}},
+		{[]interface{}{1}, [][]interface{}{{}, {"a", "b", "c"}}, []interface{}{1, "a", "b", "c"}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.First).
+			Interleave(fromCollections(caze.Others)...).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_CombineBufferSize(t *testing.T) {
+	combined := FromStream(make(Stream), 3).Merge(FromStream(make(Stream), 7), FromStream(make(Stream), 5))
+
+	assert.Equal(t, 7, combined.channelBuffer)
+	assert.Equal(t, 7, cap(combined.lastStream()))
+}
+
+func TestStreams_CombinePassesOnErrors(t *testing.T) {
+	failing := FromCollection([]interface{}{1, 2, 3}).MapErr(FailOnThree)
+
+	_, err := FromCollection([]interface{}{4}).
+		Concat(failing).
+		CollectErr(collectors.NewSliceCollector())
+
+	assert.Equal(t, errTest, err)
+}
+
+// the scanner never runs out, so this only returns if the failing input
+// stops the combined streams
+func TestStreams_CombineStopsOnFailedInput(t *testing.T) {
+	combiners := map[string]func(failing, endless *Streams) *Streams{
+		"Concat":     func(failing, endless *Streams) *Streams { return failing.Concat(endless) },
+		"Merge":      func(failing, endless *Streams) *Streams { return failing.Merge(endless) },
+		"Interleave": func(failing, endless *Streams) *Streams { return endless.Interleave(failing) },
+	}
+
+	for name, combiner := range combiners {
+		failing := FromCollection([]interface{}{1, 2, 3}).MapErr(FailOnThree)
+		endless := FromScanner(bufio.NewScanner(&EndlessReader{}), 1)
+		done := make(chan error)
+		go func() {
+			done <- combiner(failing, endless).ForEachErr(func(interface{}) error { return nil })
+		}()
+
+		select {
+		case err := <-done:
+			assert.Equal(t, errTest, err, name)
+		case <-time.After(time.Second):
+			t.Fatalf("%s kept going after an input failed", name)
+		}
+	}
+}
+
+// the scanners never run out, so this only returns if Limit stops them
+func TestStreams_CombineStopsInputs(t *testing.T) {
+	first := FromScanner(bufio.NewScanner(&EndlessReader{}), 1)
+	second := FromScanner(bufio.NewScanner(&EndlessReader{}), 1)
+
+	actual := first.Interleave(second).
+		Limit(4).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{"line", "line", "line", "line"}, actual)
+	for range first.streams[0] {
+	}
+	for range second.streams[0] {
+	}
+}
@@ -16,10 +16,26 @@ func ConsumeWithWriter(writer *bufio.Writer) streams.Consumer {
 // ConsumeWithDelimitedWriter returns a stream.Consumer function that, when called
 // writes the element to the writer, with the delimiter appended. This means you
 // have an extra delimiter at the end of whatever you're writing to.
+// Write errors are ignored; use ConsumeWithDelimitedWriterErr and
+// streams.ForEachErr to stop the stream when a write fails.
 func ConsumeWithDelimitedWriter(writer *bufio.Writer, delimter string) streams.Consumer {
+	consumer := ConsumeWithDelimitedWriterErr(writer, delimter)
 	return func(element interface{}) {
-		// Ignoring the err here is not ideal. When the lib becomes more
-		// fault tolerant, this should be changed
-		_, _ = writer.WriteString(fmt.Sprintf("%v%v", element, delimter))
+		_ = consumer(element)
+	}
+}
+
+// ConsumeWithWriterErr is ConsumeWithWriter, but the returned
+// stream.ConsumerErr returns any error from writing the element.
+func ConsumeWithWriterErr(writer *bufio.Writer) streams.ConsumerErr {
+	return ConsumeWithDelimitedWriterErr(writer, "")
+}
+
+// ConsumeWithDelimitedWriterErr is ConsumeWithDelimitedWriter, but the returned
+// stream.ConsumerErr returns any error from writing the element.
+func ConsumeWithDelimitedWriterErr(writer *bufio.Writer, delimter string) streams.ConsumerErr {
+	return func(element interface{}) error {
+		_, err := writer.WriteString(fmt.Sprintf("%v%v", element, delimter))
+		return err
 	}
 }
@@ -2,6 +2,7 @@ package consumers
 
 import (
 	"bufio"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,31 @@ func TestConsumeWithDelimitedWriter(t *testing.T) {
 		assert.Equal(t, caze.Expected, writer.Lines)
 	}
 }
+
+type FailingWriter struct{}
+
+func (writer *FailingWriter) Write(toWrite []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestConsumeWithWriterErr(t *testing.T) {
+	writer := TestWriter{[][]byte{}}
+	bufWriter := bufio.NewWriter(&writer)
+	stream := streams.FromCollection([]interface{}{"foo", "bar"})
+
+	err := stream.ForEachErr(ConsumeWithWriterErr(bufWriter))
+	_ = bufWriter.Flush()
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("foobar")}, writer.Lines)
+}
+
+func TestConsumeWithDelimitedWriterErr(t *testing.T) {
+	// a 1 byte buffer forces every write through to the failing writer
+	bufWriter := bufio.NewWriterSize(&FailingWriter{}, 1)
+	stream := streams.FromCollection([]interface{}{"foo", "bar"})
+
+	err := stream.ForEachErr(ConsumeWithDelimitedWriterErr(bufWriter, "\n"))
+
+	assert.EqualError(t, err, "disk full")
+}
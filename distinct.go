@@ -0,0 +1,168 @@
+package streams
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// KeySet is used by DistinctWith to remember which keys it has seen.
+// Add adds key to the set, returning false if key was already in it
+type KeySet interface {
+	Add(key interface{}) bool
+}
+
+// Distinct discards elements that are equal to an element seen before.
+// Every distinct element is kept in memory; see DistinctWith to bound that.
+// Elements must be comparable, as with map keys.
+func (streams *Streams) Distinct() *Streams {
+	return streams.DistinctBy(func(element interface{}) interface{} {
+		return element
+	})
+}
+
+// DistinctBy discards elements whose key, as returned by key, is equal to the
+// key of an element seen before. Every distinct key is kept in memory; see
+// DistinctWith to bound that. Keys must be comparable, as with map keys.
+func (streams *Streams) DistinctBy(key Mapper) *Streams {
+	return streams.DistinctWith(key, NewExactKeySet())
+}
+
+// DistinctWith is DistinctBy, but the keys seen thus far are remembered by
+// set. Use NewLRUKeySet or NewBloomKeySet to bound the memory used by large
+// streams, at the cost of letting some duplicates through or discarding
+// some distinct elements respectively.
+func (streams *Streams) DistinctWith(key Mapper, set KeySet) *Streams {
+	return streams.Filter(func(element interface{}) bool {
+		return set.Add(key(element))
+	})
+}
+
+// ExactKeySet is a KeySet that remembers every key added to it
+type ExactKeySet struct {
+	keys map[interface{}]struct{}
+}
+
+// NewExactKeySet creates an empty ExactKeySet and returns a pointer to it.
+func NewExactKeySet() *ExactKeySet {
+	set := ExactKeySet{map[interface{}]struct{}{}}
+	return &set
+}
+
+// Add adds key to the set, returning false if key was already in it
+func (set *ExactKeySet) Add(key interface{}) bool {
+	if _, exists := set.keys[key]; exists {
+		return false
+	}
+	set.keys[key] = struct{}{}
+	return true
+}
+
+// LRUKeySet is a KeySet that remembers only the capacity most recently
+// seen keys. A duplicate that has not been seen for longer than that
+// is treated as a new key.
+type LRUKeySet struct {
+	capacity int
+	order    *list.List
+	keys     map[interface{}]*list.Element
+}
+
+// NewLRUKeySet creates an empty LRUKeySet that remembers up to
+// capacity keys and returns a pointer to it.
+func NewLRUKeySet(capacity int) *LRUKeySet {
+	set := LRUKeySet{capacity, list.New(), map[interface{}]*list.Element{}}
+	return &set
+}
+
+// Add adds key to the set, returning false if key was already in it.
+// Either way, key becomes the most recently seen key. If the set is full,
+// the least recently seen key is forgotten.
+func (set *LRUKeySet) Add(key interface{}) bool {
+	if element, exists := set.keys[key]; exists {
+		set.order.MoveToFront(element)
+		return false
+	}
+	set.keys[key] = set.order.PushFront(key)
+	if set.order.Len() > set.capacity {
+		oldest := set.order.Back()
+		set.order.Remove(oldest)
+		delete(set.keys, oldest.Value)
+	}
+	return true
+}
+
+// BloomKeySet is a KeySet backed by a Bloom filter. It uses a fixed amount
+// of memory, but it will sometimes report a new key as already seen.
+// Keys are hashed using their %#v formatting, so they do not need to be
+// comparable.
+type BloomKeySet struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// NewBloomKeySet creates an empty BloomKeySet sized so that, after
+// expectedKeys keys have been added, a new key is wrongly reported as
+// seen with a probability of about falsePositiveRate, which must be
+// between 0 and 1, exclusive.
+func NewBloomKeySet(expectedKeys int, falsePositiveRate float64) *BloomKeySet {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic(fmt.Sprintf("streams: false positive rate %v is not between 0 and 1", falsePositiveRate))
+	}
+	if expectedKeys < 1 {
+		expectedKeys = 1
+	}
+	size := math.Ceil(-float64(expectedKeys) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	if size < 64 {
+		size = 64
+	}
+	hashes := math.Max(1, math.Round(size/float64(expectedKeys)*math.Ln2))
+	set := BloomKeySet{
+		bits:   make([]uint64, (uint64(size)+63)/64),
+		size:   uint64(size),
+		hashes: uint64(hashes),
+	}
+	return &set
+}
+
+// Add adds key to the set, returning false if key was probably already in it
+func (set *BloomKeySet) Add(key interface{}) bool {
+	added := false
+	set.eachBit(key, func(word int, mask uint64) {
+		if set.bits[word]&mask == 0 {
+			set.bits[word] |= mask
+			added = true
+		}
+	})
+	return added
+}
+
+// Contains returns true if key was probably added to the set, and false
+// if it definitely was not.
+func (set *BloomKeySet) Contains(key interface{}) bool {
+	contains := true
+	set.eachBit(key, func(word int, mask uint64) {
+		contains = contains && set.bits[word]&mask != 0
+	})
+	return contains
+}
+
+// eachBit calls visit with the location of each of the bits for key
+func (set *BloomKeySet) eachBit(key interface{}, visit func(word int, mask uint64)) {
+	sum := hashKey(key)
+	// double hashing: the i-th hash is first + i*second
+	first, second := sum&math.MaxUint32, sum>>32|1
+
+	for i := uint64(0); i < set.hashes; i++ {
+		bit := (first + i*second) % set.size
+		visit(int(bit/64), uint64(1)<<(bit%64))
+	}
+}
+
+// hashKey hashes any key using its %#v formatting, which includes its type
+func hashKey(key interface{}) uint64 {
+	hasher := fnv.New64a()
+	_, _ = fmt.Fprintf(hasher, "%#v", key)
+	return hasher.Sum64()
+}
@@ -0,0 +1,90 @@
+package streams
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type DistinctCase struct {
+	Start    []interface{}
+	Expected []interface{}
+}
+
+func TestStreams_Distinct(t *testing.T) {
+	cases := []DistinctCase{
+		{[]interface{}{}, []interface{}{}},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 2, 3}},
+		{[]interface{}{1, 2, 1, 3, 2}, []interface{}{1, 2, 3}},
+		{[]interface{}{1, "1", 1, nil, nil}, []interface{}{1, "1", nil}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			Distinct().
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_DistinctBy(t *testing.T) {
+	parity := func(element interface{}) interface{} { return element.(int) % 2 }
+
+	actual := FromCollection([]interface{}{1, 3, 4, 5, 6}).
+		DistinctBy(parity).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{1, 4}, actual)
+}
+
+func Identity(element interface{}) interface{} {
+	return element
+}
+
+func TestStreams_DistinctWithLRU(t *testing.T) {
+	// 1 has been pushed out of the window by the time it is seen again
+	actual := FromCollection([]interface{}{1, 2, 2, 3, 1, 3}).
+		DistinctWith(Identity, NewLRUKeySet(2)).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{1, 2, 3, 1}, actual)
+}
+
+func TestStreams_DistinctWithBloom(t *testing.T) {
+	actual := FromCollection([]interface{}{1, 2, 1, "1", []int{1}, []int{1}}).
+		DistinctWith(Identity, NewBloomKeySet(100, 0.01)).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{1, 2, "1", []int{1}}, actual)
+}
+
+func TestBloomKeySet_FalsePositiveRate(t *testing.T) {
+	set := NewBloomKeySet(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		set.Add(i)
+	}
+
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if set.Contains(i) {
+			falsePositives++
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, set.Contains(i))
+	}
+	// 1% of 10000 is 100, leave some room for an unlucky hash
+	assert.True(t, falsePositives < 200, "%d false positives", falsePositives)
+}
+
+func TestBloomKeySetPanicsOnBadFalsePositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -0.5, 1, 2} {
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("streams: false positive rate %v is not between 0 and 1", rate),
+			func() { NewBloomKeySet(100, rate) })
+	}
+}
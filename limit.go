@@ -0,0 +1,77 @@
+package streams
+
+// Limit passes on the first n elements of the streams, then stops every stage
+// in front of it, including the goroutine reading from a scanner, so the rest
+// of the input is never read. It stops as soon as the nth element is passed
+// on, or right away if n is not positive, without waiting for another one.
+func (streams *Streams) Limit(n int) *Streams {
+	current, next := addNewStream(streams)
+	life, stopUpstream := streams.splitLifetime()
+
+	go func() {
+		defer close(next)
+		defer stopUpstream()
+		for sent := 0; sent < n; sent++ {
+			object, ok := life.receive(current)
+			if !ok || !life.send(next, object) {
+				return
+			}
+		}
+	}()
+
+	return streams
+}
+
+// Skip discards the first n elements of the streams and passes on the rest.
+func (streams *Streams) Skip(n int) *Streams {
+	count := 0
+	return streams.DropWhile(func(_ interface{}) bool {
+		count++
+		return count <= n
+	})
+}
+
+// TakeWhile passes on elements until predicate first evaluates to false, then
+// stops every stage in front of it, like Limit. The element that made predicate
+// evaluate to false is discarded.
+func (streams *Streams) TakeWhile(predicate Predicate) *Streams {
+	current, next := addNewStream(streams)
+	life, stopUpstream := streams.splitLifetime()
+
+	go func() {
+		defer close(next)
+		defer stopUpstream()
+		for {
+			object, ok := life.receive(current)
+			if !ok || !predicate(object) || !life.send(next, object) {
+				return
+			}
+		}
+	}()
+
+	return streams
+}
+
+// DropWhile discards elements until predicate first evaluates to false, then
+// passes on that element and every element after it.
+func (streams *Streams) DropWhile(predicate Predicate) *Streams {
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		dropping := true
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			dropping = dropping && predicate(object)
+			if !dropping && !life.send(next, object) {
+				return
+			}
+		}
+	}()
+
+	return streams
+}
@@ -0,0 +1,159 @@
+package streams
+
+import (
+	"bufio"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+// EndlessReader is an io.Reader of lines that never runs out
+type EndlessReader struct{}
+
+func (reader *EndlessReader) Read(p []byte) (n int, err error) {
+	return copy(p, "line\n"), nil
+}
+
+type ShortCircuitCase struct {
+	Start    []interface{}
+	N        int
+	Expected []interface{}
+}
+
+func TestStreams_Limit(t *testing.T) {
+	cases := []ShortCircuitCase{
+		{[]interface{}{}, 2, []interface{}{}},
+		{[]interface{}{1, 2, 3}, 0, []interface{}{}},
+		{[]interface{}{1, 2, 3}, -1, []interface{}{}},
+		{[]interface{}{1, 2, 3}, 2, []interface{}{1, 2}},
+		{[]interface{}{1, 2, 3}, 5, []interface{}{1, 2, 3}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			Limit(caze.N).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_LimitStopsScanner(t *testing.T) {
+	reader := EndlessReader{}
+	stream := FromScanner(bufio.NewScanner(&reader), 1)
+
+	actual := stream.
+		Map(func(element interface{}) interface{} { return element }).
+		Limit(3).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{"line", "line", "line"}, actual)
+	// the scanner goroutine has to exit, or this would block forever
+	for range stream.streams[0] {
+	}
+}
+
+func TestStreams_LimitDoesNotWaitForMore(t *testing.T) {
+	cases := []ShortCircuitCase{
+		{[]interface{}{}, 0, []interface{}{}},
+		{[]interface{}{1, 2}, 2, []interface{}{1, 2}},
+	}
+
+	for _, caze := range cases {
+		// the source sends its elements, then stays open without sending more
+		source := make(Stream, len(caze.Start))
+		for _, element := range caze.Start {
+			source <- element
+		}
+		done := make(chan interface{})
+
+		go func() {
+			defer close(done)
+			actual := FromStream(source, 0).
+				Limit(caze.N).
+				Collect(collectors.NewSliceCollector())
+			assert.Equal(t, caze.Expected, actual)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("Limit(%d) waited for another element", caze.N)
+		}
+	}
+}
+
+func TestStreams_Skip(t *testing.T) {
+	cases := []ShortCircuitCase{
+		{[]interface{}{}, 2, []interface{}{}},
+		{[]interface{}{1, 2, 3}, 0, []interface{}{1, 2, 3}},
+		{[]interface{}{1, 2, 3}, 2, []interface{}{3}},
+		{[]interface{}{1, 2, 3}, 5, []interface{}{}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			Skip(caze.N).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func LessThanThree(element interface{}) bool {
+	return element.(int) < 3
+}
+
+type WhileCase struct {
+	Start    []interface{}
+	Expected []interface{}
+}
+
+func TestStreams_TakeWhile(t *testing.T) {
+	cases := []WhileCase{
+		{[]interface{}{}, []interface{}{}},
+		{[]interface{}{1, 2, 3, 1}, []interface{}{1, 2}},
+		{[]interface{}{3, 1}, []interface{}{}},
+		{[]interface{}{1, 2}, []interface{}{1, 2}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			TakeWhile(LessThanThree).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_DropWhile(t *testing.T) {
+	cases := []WhileCase{
+		{[]interface{}{}, []interface{}{}},
+		{[]interface{}{1, 2, 3, 1}, []interface{}{3, 1}},
+		{[]interface{}{3, 1}, []interface{}{3, 1}},
+		{[]interface{}{1, 2}, []interface{}{}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			DropWhile(LessThanThree).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+// stopping the stages in front of Limit must not lose the elements
+// already waiting in the stages after it
+func TestStreams_LimitKeepsDownstream(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		actual := FromScanner(bufio.NewScanner(&EndlessReader{}), 16).
+			Limit(10).
+			Map(func(element interface{}) interface{} { return element }).
+			Reduce(0, func(count, _ interface{}) interface{} { return count.(int) + 1 })
+
+		assert.Equal(t, 10, actual)
+	}
+}
@@ -19,7 +19,8 @@ func FloatToStringMapper(element interface{}) interface{} {
 
 // StringToIntMapper converts elements from string to int
 // Elements that cannot be converted result in the 0 value being added instead.
-// To avoid this, it may be worthwhile to use streams.Filter first.
+// To avoid this, it may be worthwhile to use streams.Filter first, or
+// StringToIntMapperErr to stop the stream instead.
 func StringToIntMapper(element interface{}) interface{} {
 	asInt, err := strconv.Atoi(element.(string))
 
@@ -29,6 +30,12 @@ func StringToIntMapper(element interface{}) interface{} {
 	return 0
 }
 
+// StringToIntMapperErr converts elements from string to int.
+// Elements that cannot be converted result in an error. Use with streams.MapErr
+func StringToIntMapperErr(element interface{}) (interface{}, error) {
+	return strconv.Atoi(element.(string))
+}
+
 // StringToFloatMapper returns a function that converts elements
 // from string to float of floatSize (either 32 or 64). Elements that
 // cannot be converted result in a mapping to the float 0.0.
@@ -44,6 +51,15 @@ func StringToFloatMapper(floatSize int) streams.Mapper {
 	}
 }
 
+// StringToFloatMapperErr returns a function that converts elements
+// from string to float of floatSize (either 32 or 64). Elements that
+// cannot be converted result in an error. Use with streams.MapErr
+func StringToFloatMapperErr(floatSize int) streams.MapperErr {
+	return func(element interface{}) (interface{}, error) {
+		return strconv.ParseFloat(element.(string), floatSize)
+	}
+}
+
 // EntryCreator gets the key and value from an element
 type EntryCreator func(element interface{}) (interface{}, interface{})
 
@@ -79,6 +79,70 @@ func TestStringToIntMapper(t *testing.T) {
 	}
 }
 
+// elements mapped before the failing one may or may not reach the
+// collector, so Expected is only checked when there is no error
+type MapperErrCase struct {
+	Start    []interface{}
+	Expected []interface{}
+	Err      bool
+}
+
+func TestStringToIntMapperErr(t *testing.T) {
+	cases := []MapperErrCase{
+		{
+			[]interface{}{},
+			[]interface{}{},
+			false,
+		}, {
+			[]interface{}{"1", "2", "3"},
+			[]interface{}{1, 2, 3},
+			false,
+		}, {
+			[]interface{}{"1", "2.0", "three"},
+			nil,
+			true,
+		},
+	}
+
+	for _, caze := range cases {
+		stream := streams.FromCollection(caze.Start)
+		actual, err := stream.
+			MapErr(StringToIntMapperErr).
+			CollectErr(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Err, err != nil)
+		if !caze.Err {
+			assert.Equal(t, caze.Expected, actual)
+		}
+	}
+}
+
+func TestStringToFloatMapperErr(t *testing.T) {
+	cases := []MapperErrCase{
+		{
+			[]interface{}{"1.25", "2.25"},
+			[]interface{}{1.25, 2.25},
+			false,
+		}, {
+			[]interface{}{"1.25", "foo"},
+			nil,
+			true,
+		},
+	}
+
+	for _, caze := range cases {
+		stream := streams.FromCollection(caze.Start)
+		actual, err := stream.
+			MapErr(StringToFloatMapperErr(64)).
+			CollectErr(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Err, err != nil)
+		if !caze.Err {
+			assert.Equal(t, caze.Expected, actual)
+		}
+	}
+}
+
 type FLoatToIntMapperCase struct {
 	Start     []interface{}
 	FloatSize int
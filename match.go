@@ -0,0 +1,61 @@
+package streams
+
+import (
+	"errors"
+)
+
+// errShortCircuit is returned by the consumer of a terminal operation that
+// has its answer before the end of the streams. It stops the streams
+// without being reported by Err.
+var errShortCircuit = errors.New("streams: short circuit")
+
+// AnyMatch returns true as soon as an element causes predicate to evaluate
+// to true, stopping every stage of the streams. It returns false for an
+// empty stream.
+func (streams *Streams) AnyMatch(predicate Predicate) bool {
+	matched := false
+	_ = streams.each(func(element interface{}) error {
+		if predicate(element) {
+			matched = true
+			return errShortCircuit
+		}
+		return nil
+	})
+	return matched
+}
+
+// AllMatch returns false as soon as an element causes predicate to evaluate
+// to false, stopping every stage of the streams. It returns true for an
+// empty stream.
+func (streams *Streams) AllMatch(predicate Predicate) bool {
+	return !streams.AnyMatch(func(element interface{}) bool {
+		return !predicate(element)
+	})
+}
+
+// NoneMatch returns false as soon as an element causes predicate to evaluate
+// to true, stopping every stage of the streams. It returns true for an
+// empty stream.
+func (streams *Streams) NoneMatch(predicate Predicate) bool {
+	return !streams.AnyMatch(predicate)
+}
+
+// FindFirst returns the first element of the streams and true, stopping
+// every stage of the streams. It returns nil and false for an empty stream.
+func (streams *Streams) FindFirst() (interface{}, bool) {
+	var found interface{}
+	ok := streams.AnyMatch(func(element interface{}) bool {
+		found = element
+		return true
+	})
+	return found, ok
+}
+
+// FindAny returns any element of the streams and true, stopping every stage
+// of the streams. It returns nil and false for an empty stream.
+// It is FindFirst without the promise of which element is returned, which
+// only makes a difference after unordered parallel stages like ParallelMap,
+// where it returns whichever element was finished first.
+func (streams *Streams) FindAny() (interface{}, bool) {
+	return streams.FindFirst()
+}
@@ -0,0 +1,79 @@
+package streams
+
+import (
+	"bufio"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MatchCase struct {
+	Start     []interface{}
+	Predicate Predicate
+	Any       bool
+	All       bool
+	None      bool
+}
+
+func TestStreams_Match(t *testing.T) {
+	cases := []MatchCase{
+		{[]interface{}{}, EvenPredicate, false, true, true},
+		{[]interface{}{1, 3}, EvenPredicate, false, false, true},
+		{[]interface{}{1, 2}, EvenPredicate, true, false, false},
+		{[]interface{}{2, 4}, EvenPredicate, true, true, false},
+	}
+
+	for _, caze := range cases {
+		assert.Equal(t, caze.Any, FromCollection(caze.Start).AnyMatch(caze.Predicate))
+		assert.Equal(t, caze.All, FromCollection(caze.Start).AllMatch(caze.Predicate))
+		assert.Equal(t, caze.None, FromCollection(caze.Start).NoneMatch(caze.Predicate))
+	}
+}
+
+type FindCase struct {
+	Start    []interface{}
+	Expected interface{}
+	Found    bool
+}
+
+func TestStreams_FindFirst(t *testing.T) {
+	cases := []FindCase{
+		{[]interface{}{}, nil, false},
+		{[]interface{}{nil}, nil, true},
+		{[]interface{}{1, 2, 3}, 1, true},
+	}
+
+	for _, caze := range cases {
+		actual, found := FromCollection(caze.Start).FindFirst()
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Found, found)
+	}
+}
+
+func TestStreams_FindAny(t *testing.T) {
+	actual, found := FromCollection([]interface{}{1, 2, 3}).
+		ParallelMap(3, MapDoubleVal, false).
+		FindAny()
+
+	assert.True(t, found)
+	assert.Contains(t, []interface{}{2, 4, 6}, actual)
+}
+
+// the scanner never runs out, so these only return if they stop it
+func TestStreams_MatchStopsUpstream(t *testing.T) {
+	isLine := func(element interface{}) bool { return element == "line" }
+
+	stream := FromScanner(bufio.NewScanner(&EndlessReader{}), 1).Filter(AcceptAllPredicate)
+	assert.True(t, stream.AnyMatch(isLine))
+	assert.Nil(t, stream.Err())
+	for range stream.streams[0] {
+	}
+
+	stream = FromScanner(bufio.NewScanner(&EndlessReader{}), 1)
+	assert.False(t, stream.NoneMatch(isLine))
+
+	first, found := FromScanner(bufio.NewScanner(&EndlessReader{}), 1).FindFirst()
+	assert.Equal(t, "line", first)
+	assert.True(t, found)
+}
@@ -0,0 +1,236 @@
+package streams
+
+import (
+	"sync"
+)
+
+type parallelJob struct {
+	sequence int
+	element  interface{}
+}
+
+type parallelResult struct {
+	sequence int
+	elements []interface{}
+}
+
+// ParallelMap is Map, but mapper is called from workers goroutines at once.
+// Use this when mapper is slow enough to hold up the rest of the stream.
+// If ordered is true, the mapped elements keep the order of the stream, at the
+// cost of holding back finished elements until the ones before them are done.
+// If ordered is false, elements are passed on as soon as they are mapped.
+func (streams *Streams) ParallelMap(workers int, mapper Mapper, ordered bool) *Streams {
+	return streams.addParallelStage(workers, ordered, func(element interface{}) []interface{} {
+		return []interface{}{mapper(element)}
+	})
+}
+
+// ParallelFilter is Filter, but predicate is called from workers goroutines at once.
+// See ParallelMap for what ordered does.
+func (streams *Streams) ParallelFilter(workers int, predicate Predicate, ordered bool) *Streams {
+	return streams.addParallelStage(workers, ordered, func(element interface{}) []interface{} {
+		if predicate(element) {
+			return []interface{}{element}
+		}
+		return nil
+	})
+}
+
+// ParallelFlatMap is FlatMap, but mapper is called from workers goroutines at once.
+// See ParallelMap for what ordered does. The elements from a single call to
+// mapper are always kept together and in order.
+func (streams *Streams) ParallelFlatMap(workers int, mapper FlatMapper, ordered bool) *Streams {
+	return streams.addParallelStage(workers, ordered, mapper)
+}
+
+// addParallelStage adds a stage made of a dispatcher goroutine that numbers the
+// elements of the current stream, workers goroutines that call process, and an
+// emitter goroutine that puts the results on the next stream. At most
+// workers + channelBuffer elements are in flight at once, which bounds how many
+// results the emitter has to hold back to keep them ordered.
+func (streams *Streams) addParallelStage(workers int, ordered bool, process func(element interface{}) []interface{}) *Streams {
+	if workers < 1 {
+		workers = 1
+	}
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+	jobs := make(chan parallelJob)
+	results := make(chan parallelResult, workers)
+	inFlight := make(chan struct{}, workers+streams.channelBuffer)
+
+	go func() {
+		defer close(jobs)
+		for sequence := 0; ; sequence++ {
+			element, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			select {
+			case inFlight <- struct{}{}:
+			case <-life.ctx.Done():
+				return
+			case <-life.stop:
+				return
+			}
+			select {
+			case jobs <- parallelJob{sequence, element}:
+			case <-life.ctx.Done():
+				return
+			case <-life.stop:
+				return
+			}
+		}
+	}()
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer waitGroup.Done()
+			for job := range jobs {
+				select {
+				case results <- parallelResult{job.sequence, process(job.element)}:
+				case <-life.ctx.Done():
+					return
+				case <-life.stop:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		waitGroup.Wait()
+		close(results)
+	}()
+
+	go func() {
+		defer close(next)
+		emit := func(elements []interface{}) bool {
+			for _, element := range elements {
+				if !life.send(next, element) {
+					return false
+				}
+			}
+			<-inFlight
+			return true
+		}
+
+		pending := map[int][]interface{}{}
+		expected := 0
+		for result := range results {
+			if !ordered {
+				if !emit(result.elements) {
+					return
+				}
+				continue
+			}
+
+			pending[result.sequence] = result.elements
+			for {
+				elements, ok := pending[expected]
+				if !ok {
+					break
+				}
+				delete(pending, expected)
+				expected++
+				if !emit(elements) {
+					return
+				}
+			}
+		}
+	}()
+
+	return streams
+}
+
+// ParallelReduce is Reduce spread over workers goroutines. Each worker folds
+// the elements it takes off the stream into its own reduction, starting from
+// identity, using accumulator. The reductions of the workers are then folded
+// together, in no particular order, using combiner.
+// This only gives the same result as Reduce if accumulator and combiner are
+// associative, and identity changes nothing when reduced with another value,
+// like 0 for a sum. Which elements a worker sees is not predictable.
+func (streams *Streams) ParallelReduce(workers int, identity interface{}, accumulator, combiner Reducer) interface{} {
+	reduction, _ := streams.ParallelReduceErr(workers, identity, accumulator, combiner)
+	return reduction
+}
+
+// ParallelReduceErr is ParallelReduce, but it also returns the error that
+// stopped the streams early, if any. See Err.
+func (streams *Streams) ParallelReduceErr(workers int, identity interface{}, accumulator, combiner Reducer) (interface{}, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	life := streams.lifetime()
+	lastStream := streams.lastStream()
+	defer streams.halt()
+
+	partials := make([]interface{}, workers)
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(workers)
+	for worker := range partials {
+		go func(worker int) {
+			defer waitGroup.Done()
+			partial := identity
+			for {
+				element, ok := life.receive(lastStream)
+				if !ok {
+					partials[worker] = partial
+					return
+				}
+				partial = accumulator(partial, element)
+			}
+		}(worker)
+	}
+	waitGroup.Wait()
+
+	reduction := identity
+	for _, partial := range partials {
+		reduction = combiner(reduction, partial)
+	}
+	return reduction, streams.finish(life)
+}
+
+// ParallelCollect is Collect spread over workers goroutines. Each worker adds
+// the elements it takes off the stream to its own collector from
+// collector.Supplier, and those are then combined into collector, which is
+// completed and returned. Which elements a worker sees is not predictable, so
+// for collectors like collectors.SliceCollector the order is lost.
+func (streams *Streams) ParallelCollect(workers int, collector ConcurrentCollector) interface{} {
+	collection, _ := streams.ParallelCollectErr(workers, collector)
+	return collection
+}
+
+// ParallelCollectErr is ParallelCollect, but it also returns the error that
+// stopped the streams early, if any. See Err.
+func (streams *Streams) ParallelCollectErr(workers int, collector ConcurrentCollector) (interface{}, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	life := streams.lifetime()
+	lastStream := streams.lastStream()
+	defer streams.halt()
+
+	partials := make([]Collector, workers)
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(workers)
+	for worker := range partials {
+		partials[worker] = collector.Supplier()
+		go func(partial Collector) {
+			defer waitGroup.Done()
+			for {
+				element, ok := life.receive(lastStream)
+				if !ok {
+					return
+				}
+				partial.Add(element)
+			}
+		}(partials[worker])
+	}
+	waitGroup.Wait()
+
+	for _, partial := range partials {
+		collector.Combine(partial)
+	}
+	return streams.complete(collector, streams.finish(life))
+}
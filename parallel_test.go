@@ -0,0 +1,176 @@
+package streams
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+// sleeping longer for smaller elements makes later elements finish first
+func SlowDoubleVal(subject interface{}) interface{} {
+	time.Sleep(time.Duration(10-subject.(int)) * time.Millisecond)
+	return MapDoubleVal(subject)
+}
+
+func sortedInts(elements interface{}) []interface{} {
+	sorted := append([]interface{}{}, elements.([]interface{})...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].(int) < sorted[j].(int) })
+	return sorted
+}
+
+type ParallelMapCase struct {
+	Workers  int
+	Start    []interface{}
+	Expected []interface{}
+}
+
+func TestStreams_ParallelMap(t *testing.T) {
+	cases := []ParallelMapCase{
+		{4, []interface{}{}, []interface{}{}},
+		{0, []interface{}{1, 2, 3}, []interface{}{2, 4, 6}},
+		{1, []interface{}{1, 2, 3}, []interface{}{2, 4, 6}},
+		{4, []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9}, []interface{}{2, 4, 6, 8, 10, 12, 14, 16, 18}},
+	}
+
+	for _, caze := range cases {
+		ordered := FromCollection(caze.Start).
+			ParallelMap(caze.Workers, SlowDoubleVal, true).
+			Collect(collectors.NewSliceCollector())
+		unordered := FromCollection(caze.Start).
+			ParallelMap(caze.Workers, SlowDoubleVal, false).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, ordered)
+		assert.Equal(t, caze.Expected, sortedInts(unordered))
+	}
+}
+
+func TestStreams_ParallelMapRunsConcurrently(t *testing.T) {
+	var running, most int32
+	mapper := func(element interface{}) interface{} {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&most)
+			if now <= seen || atomic.CompareAndSwapInt32(&most, seen, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return element
+	}
+
+	FromCollection([]interface{}{1, 2, 3, 4, 5, 6, 7, 8}).
+		ParallelMap(4, mapper, true).
+		ForEach(func(_ interface{}) {})
+
+	assert.True(t, atomic.LoadInt32(&most) > 1)
+	assert.True(t, atomic.LoadInt32(&most) <= 4)
+}
+
+func TestStreams_ParallelFilter(t *testing.T) {
+	start := []interface{}{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ordered := FromCollection(start).
+		ParallelFilter(3, EvenPredicate, true).
+		Collect(collectors.NewSliceCollector())
+	unordered := FromCollection(start).
+		ParallelFilter(3, EvenPredicate, false).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{2, 4, 6, 8}, ordered)
+	assert.Equal(t, []interface{}{2, 4, 6, 8}, sortedInts(unordered))
+}
+
+func TestStreams_ParallelFlatMap(t *testing.T) {
+	start := []interface{}{0, 1, 2, 3}
+
+	ordered := FromCollection(start).
+		ParallelFlatMap(3, CountFromZero, true).
+		Collect(collectors.NewSliceCollector())
+	unordered := FromCollection(start).
+		ParallelFlatMap(3, CountFromZero, false).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{1, 1, 2, 1, 2, 3}, ordered)
+	assert.Equal(t, []interface{}{1, 1, 1, 2, 2, 3}, sortedInts(unordered))
+}
+
+func TestStreams_ParallelMapCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := FromStream(make(Stream), 0).
+		WithContext(ctx).
+		ParallelMap(4, MapDoubleVal, true)
+	cancel()
+
+	// the stage closes its Stream once cancelled, even though no terminal ran
+	for range stream.lastStream() {
+	}
+}
+
+type ParallelReduceCase struct {
+	Workers  int
+	Start    []interface{}
+	Identity interface{}
+	Expected interface{}
+}
+
+func TestStreams_ParallelReduce(t *testing.T) {
+	cases := []ParallelReduceCase{
+		{4, []interface{}{}, 0, 0},
+		{0, []interface{}{1, 2, 3}, 0, 6},
+		{4, []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 55},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			ParallelReduce(caze.Workers, caze.Identity, ReduceToSum, ReduceToSum)
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_ParallelReduceCombiner(t *testing.T) {
+	count := func(count, _ interface{}) interface{} { return count.(int) + 1 }
+	start := make([]interface{}, 1000)
+
+	actual := FromCollection(start).ParallelReduce(8, 0, count, ReduceToSum)
+
+	assert.Equal(t, 1000, actual)
+}
+
+func TestStreams_ParallelReduceErr(t *testing.T) {
+	_, err := FromCollection([]interface{}{1, 2, 3, 4}).
+		MapErr(FailOnThree).
+		ParallelReduceErr(2, 0, ReduceToSum, ReduceToSum)
+
+	assert.Equal(t, errTest, err)
+}
+
+func TestStreams_ParallelCollect(t *testing.T) {
+	cases := []ParallelMapCase{
+		{4, []interface{}{}, []interface{}{}},
+		{0, []interface{}{1, 2, 3}, []interface{}{1, 2, 3}},
+		{4, []interface{}{5, 4, 3, 2, 1, 6, 7, 8}, []interface{}{1, 2, 3, 4, 5, 6, 7, 8}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			ParallelCollect(caze.Workers, collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, sortedInts(actual))
+	}
+}
+
+func TestStreams_ParallelCollectErr(t *testing.T) {
+	_, err := FromCollection([]interface{}{1, 2, 3, 4}).
+		MapErr(FailOnThree).
+		ParallelCollectErr(2, collectors.NewSliceCollector())
+
+	assert.Equal(t, errTest, err)
+}
@@ -0,0 +1,46 @@
+package streams
+
+// Partition splits the streams in two in a single pass: matched gets the
+// elements that cause predicate to evaluate to true, unmatched gets the rest.
+// Both have to be consumed at the same time, as a slow one holds the other
+// back; see PartitionWith to change that. Do not add anything else to the
+// streams Partition was called on. See Broadcast for how the new streams
+// objects stop and report errors.
+func (streams *Streams) Partition(predicate Predicate) (matched, unmatched *Streams) {
+	return streams.PartitionWith(predicate, BroadcastOptions{})
+}
+
+// PartitionWith is Partition configured with options, which work like they
+// do for Broadcast. With SpillForSlowBranches, matched can be consumed in
+// full before unmatched.
+func (streams *Streams) PartitionWith(predicate Predicate, options BroadcastOptions) (matched, unmatched *Streams) {
+	partitions := streams.RouteByWith(func(element interface{}) interface{} {
+		if predicate(element) {
+			return 0
+		}
+		return 1
+	}, 2, options)
+	return partitions[0], partitions[1]
+}
+
+// RouteBy splits the streams into n new streams objects in a single pass,
+// sending each element to one of them according to the key returned by key.
+// Elements with equal keys always go to the same streams object. An int key
+// picks the streams object directly, modulo n; any other key is hashed using
+// its %#v formatting. See Partition for how the new streams objects are used.
+func (streams *Streams) RouteBy(key Mapper, n int) []*Streams {
+	return streams.RouteByWith(key, n, BroadcastOptions{})
+}
+
+// RouteByWith is RouteBy configured with options, which work like they do
+// for Broadcast
+func (streams *Streams) RouteByWith(key Mapper, n int, options BroadcastOptions) []*Streams {
+	return streams.fanOut(n, options, func(element interface{}) int {
+		switch typed := key(element).(type) {
+		case int:
+			return (typed%n + n) % n
+		default:
+			return int(hashKey(typed) % uint64(n))
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package streams
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type PartitionCase struct {
+	Start     []interface{}
+	Matched   []interface{}
+	Unmatched []interface{}
+}
+
+func TestStreams_Partition(t *testing.T) {
+	cases := []PartitionCase{
+		{[]interface{}{}, []interface{}{}, []interface{}{}},
+		{[]interface{}{1, 2, 3, 4}, []interface{}{2, 4}, []interface{}{1, 3}},
+		{[]interface{}{2, 4}, []interface{}{2, 4}, []interface{}{}},
+	}
+
+	for _, caze := range cases {
+		matched, unmatched := FromCollection(caze.Start).Partition(EvenPredicate)
+		actual, _ := collectAll([]*Streams{matched, unmatched})
+
+		assert.Equal(t, []interface{}{caze.Matched, caze.Unmatched}, actual)
+	}
+}
+
+func TestStreams_RouteBy(t *testing.T) {
+	mod3 := func(element interface{}) interface{} { return element.(int) % 3 }
+
+	branches := FromCollection([]interface{}{0, 1, 2, 3, 4, 5, -1}).RouteBy(mod3, 3)
+	actual, _ := collectAll(branches)
+
+	// -1 % 3 is -1, which wraps around to the last branch
+	expected := []interface{}{[]interface{}{0, 3}, []interface{}{1, 4}, []interface{}{2, 5, -1}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestStreams_RouteByHashedKeys(t *testing.T) {
+	first := func(element interface{}) interface{} { return element.(string)[:1] }
+
+	branches := FromCollection([]interface{}{"a1", "b1", "a2", "c1", "b2", "a3"}).RouteBy(first, 2)
+	actual, _ := collectAll(branches)
+
+	// every element with the same key ends up in the same branch, in order
+	byKey := map[string][]interface{}{}
+	total := 0
+	for _, branch := range actual {
+		seen := map[string]bool{}
+		for _, element := range branch.([]interface{}) {
+			seen[first(element).(string)] = true
+			total++
+		}
+		for key := range seen {
+			byKey[key] = branch.([]interface{})
+		}
+	}
+	assert.Equal(t, 6, total)
+	assert.Len(t, byKey, 3)
+	assert.Subset(t, byKey["a"], []interface{}{"a1", "a2", "a3"})
+	assert.Subset(t, byKey["b"], []interface{}{"b1", "b2"})
+}
+
+func TestStreams_PartitionOneSideStopsEarly(t *testing.T) {
+	matched, unmatched := FromCollection([]interface{}{1, 2, 3, 4, 5, 6}).Partition(EvenPredicate)
+	matched.Limit(1)
+
+	actual, _ := collectAll([]*Streams{matched, unmatched})
+
+	assert.Equal(t, []interface{}{[]interface{}{2}, []interface{}{1, 3, 5}}, actual)
+}
+
+func TestStreams_PartitionWithSpillOneSideAtATime(t *testing.T) {
+	lines := ""
+	expectedValid, expectedInvalid := []interface{}{}, []interface{}{}
+	for i := 0; i < 100; i++ {
+		line := strconv.Itoa(i)
+		lines += line + "\n"
+		if i%2 == 0 {
+			expectedValid = append(expectedValid, line)
+		} else {
+			expectedInvalid = append(expectedInvalid, line)
+		}
+	}
+	isValid := func(element interface{}) bool {
+		number, _ := strconv.Atoi(element.(string))
+		return number%2 == 0
+	}
+	done := make(chan []interface{})
+
+	go func() {
+		valid, invalid := FromScanner(bufio.NewScanner(strings.NewReader(lines)), 4).
+			PartitionWith(isValid, BroadcastOptions{Policy: SpillForSlowBranches, TempDir: t.TempDir()})
+		// invalid is not read at all until valid is done
+		done <- []interface{}{
+			valid.Collect(collectors.NewSliceCollector()),
+			invalid.Collect(collectors.NewSliceCollector()),
+		}
+	}()
+
+	select {
+	case actual := <-done:
+		assert.Equal(t, []interface{}{expectedValid, expectedInvalid}, actual)
+	case <-time.After(time.Second):
+		t.Fatal("the unread partition held back the other one")
+	}
+}
@@ -0,0 +1,17 @@
+package streams
+
+import "math/rand"
+
+// Sample passes on each element with a probability of fraction, which must
+// be between 0 and 1, so roughly that fraction of the stream gets through.
+// The same stream sampled with the same seed passes on the same elements.
+// Use collectors.ReservoirSampleCollector for a sample of an exact size.
+func (streams *Streams) Sample(fraction float64, seed int64) *Streams {
+	if fraction < 0 || fraction > 1 {
+		panic("streams: sample fraction is not between 0 and 1")
+	}
+	random := rand.New(rand.NewSource(seed))
+	return streams.Filter(func(_ interface{}) bool {
+		return random.Float64() < fraction
+	})
+}
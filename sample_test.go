@@ -0,0 +1,39 @@
+package streams
+
+import (
+	"testing"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+func rangeOf(n int) []interface{} {
+	elements := make([]interface{}, n)
+	for i := range elements {
+		elements[i] = i
+	}
+	return elements
+}
+
+func TestStreams_Sample(t *testing.T) {
+	elements := rangeOf(10000)
+
+	first := FromCollection(elements).Sample(0.1, 42).Collect(collectors.NewSliceCollector()).([]interface{})
+	second := FromCollection(elements).Sample(0.1, 42).Collect(collectors.NewSliceCollector()).([]interface{})
+	other := FromCollection(elements).Sample(0.1, 7).Collect(collectors.NewSliceCollector()).([]interface{})
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.InDelta(t, 1000, len(first), 100)
+}
+
+func TestStreams_Sample_Bounds(t *testing.T) {
+	elements := rangeOf(100)
+
+	none := FromCollection(elements).Sample(0, 1).Collect(collectors.NewSliceCollector())
+	all := FromCollection(elements).Sample(1, 1).Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{}, none)
+	assert.Equal(t, elements, all)
+	assert.Panics(t, func() { FromCollection(elements).Sample(1.5, 1) })
+}
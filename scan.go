@@ -0,0 +1,43 @@
+package streams
+
+// StatefulMapper is used by StatefulMap. It gets the state thus far and an
+// element, and returns the new state and the element to pass on.
+type StatefulMapper func(state, element interface{}) (newState, mapped interface{})
+
+// Scan is Reduce as a stage: instead of only returning the final reduction,
+// it passes on the reduction after each element. The first parameter of
+// reducer is the reduction thus far, starting with initial, which is not
+// passed on itself. Use it for running totals and the like.
+func (streams *Streams) Scan(initial interface{}, reducer Reducer) *Streams {
+	return streams.StatefulMap(initial, func(state, element interface{}) (interface{}, interface{}) {
+		reduction := reducer(state, element)
+		return reduction, reduction
+	})
+}
+
+// StatefulMap is Map for mappers that need to remember something from one
+// element to the next. Rather than capturing that state in a closure, mapper
+// is passed the state, starting with initial, and returns the new state along
+// with the mapped element.
+func (streams *Streams) StatefulMap(initial interface{}, mapper StatefulMapper) *Streams {
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		state := initial
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			var mapped interface{}
+			state, mapped = mapper(state, object)
+			if !life.send(next, mapped) {
+				return
+			}
+		}
+	}()
+
+	return streams
+}
@@ -0,0 +1,48 @@
+package streams
+
+import (
+	"testing"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type ScanCase struct {
+	Reducer  Reducer
+	Start    []interface{}
+	Initial  interface{}
+	Expected []interface{}
+}
+
+func TestStreams_Scan(t *testing.T) {
+	cases := []ScanCase{
+		{ReduceToSum, []interface{}{}, 0, []interface{}{}},
+		{ReduceToSum, []interface{}{1, 2, 3}, 0, []interface{}{1, 3, 6}},
+		{ReduceToSum, []interface{}{1, 2, 3}, 10, []interface{}{11, 13, 16}},
+		{ReduceToMax, []interface{}{2, 1, 3, 0}, -1, []interface{}{2, 2, 3, 3}},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			Scan(caze.Initial, caze.Reducer).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+// Delta maps each element to how much it changed since the one before it
+func Delta(state, element interface{}) (interface{}, interface{}) {
+	if state == nil {
+		return element, 0
+	}
+	return element, element.(int) - state.(int)
+}
+
+func TestStreams_StatefulMap(t *testing.T) {
+	actual := FromCollection([]interface{}{1, 4, 4, 2}).
+		StatefulMap(nil, Delta).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{0, 3, 0, -2}, actual)
+}
@@ -0,0 +1,332 @@
+package streams
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Less reports whether first should be sorted before second
+type Less func(first, second interface{}) bool
+
+// DefaultMaxInMemory is the number of elements Sorted holds in memory
+// before it starts spilling sorted runs to disk
+const DefaultMaxInMemory = 1 << 20
+
+// SortOptions configures SortedWith. The zero value of each field
+// means the default.
+// MaxInMemory is the number of elements kept in memory before a sorted run
+// is written to a temp file. Defaults to DefaultMaxInMemory.
+// Codec writes and reads the elements of those runs. Defaults to GobCodec.
+// TempDir is where the runs are written. Defaults to os.TempDir.
+type SortOptions struct {
+	MaxInMemory int
+	Codec       SpillCodec
+	TempDir     string
+}
+
+// SpillCodec is used by SortedWith to write elements to disk and read
+// them back.
+type SpillCodec interface {
+	NewEncoder(writer io.Writer) SpillEncoder
+	NewDecoder(reader io.Reader) SpillDecoder
+}
+
+// SpillEncoder writes elements, one Encode call at a time
+type SpillEncoder interface {
+	Encode(element interface{}) error
+}
+
+// SpillDecoder reads back the elements written by a SpillEncoder, in the same
+// order. Decode returns io.EOF once there are no more elements.
+type SpillDecoder interface {
+	Decode() (interface{}, error)
+}
+
+// GobCodec is a SpillCodec that uses encoding/gob. Elements of types other
+// than the built in ones have to be registered with gob.Register first.
+type GobCodec struct{}
+
+// NewEncoder returns a SpillEncoder that gob encodes elements to writer
+func (GobCodec) NewEncoder(writer io.Writer) SpillEncoder {
+	return gobEncoder{gob.NewEncoder(writer)}
+}
+
+// NewDecoder returns a SpillDecoder that gob decodes elements from reader
+func (GobCodec) NewDecoder(reader io.Reader) SpillDecoder {
+	return gobDecoder{gob.NewDecoder(reader)}
+}
+
+type gobEncoder struct {
+	encoder *gob.Encoder
+}
+
+func (encoder gobEncoder) Encode(element interface{}) error {
+	// encoding a pointer to the interface keeps the element's type in the stream
+	return encoder.encoder.Encode(&element)
+}
+
+type gobDecoder struct {
+	decoder *gob.Decoder
+}
+
+func (decoder gobDecoder) Decode() (interface{}, error) {
+	var element interface{}
+	err := decoder.decoder.Decode(&element)
+	return element, err
+}
+
+// Sorted sorts the elements of the streams using less. Like every sort, it has
+// to see the last element before it can pass on the first one. Elements that are
+// equal according to less keep their order. See SortedWith for the memory used.
+func (streams *Streams) Sorted(less Less) *Streams {
+	return streams.SortedWith(less, SortOptions{})
+}
+
+// SortedBy sorts the elements of the streams by the key returned by key. Keys
+// must all be ints, uints, floats or strings of the same type, and are sorted
+// in their natural order.
+func (streams *Streams) SortedBy(key Mapper) *Streams {
+	return streams.Sorted(func(first, second interface{}) bool {
+		return naturalLess(key(first), key(second))
+	})
+}
+
+// SortedWith is Sorted, but once options.MaxInMemory elements have been
+// read, they are sorted and written to a temp file using options.Codec.
+// Once the streams are done, those runs are merged back together, so
+// no more than options.MaxInMemory elements are ever held in memory.
+// Errors writing or reading the runs stop the streams; see Err.
+func (streams *Streams) SortedWith(less Less, options SortOptions) *Streams {
+	if options.MaxInMemory < 1 {
+		options.MaxInMemory = DefaultMaxInMemory
+	}
+	if options.Codec == nil {
+		options.Codec = GobCodec{}
+	}
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		sorter := externalSorter{less: less, options: options}
+		defer sorter.cleanUp()
+
+		for {
+			element, ok := life.receive(current)
+			if !ok {
+				break
+			}
+			if err := sorter.add(element); err != nil {
+				streams.fail(err)
+				return
+			}
+		}
+		if life.stopped() {
+			return
+		}
+
+		err := sorter.merge(func(element interface{}) bool {
+			return life.send(next, element)
+		})
+		if err != nil {
+			streams.fail(err)
+		}
+	}()
+
+	return streams
+}
+
+// naturalLess compares ints, uints, floats and strings of the same type
+func naturalLess(first, second interface{}) bool {
+	switch typed := first.(type) {
+	case int:
+		return typed < second.(int)
+	case int8:
+		return typed < second.(int8)
+	case int16:
+		return typed < second.(int16)
+	case int32:
+		return typed < second.(int32)
+	case int64:
+		return typed < second.(int64)
+	case uint:
+		return typed < second.(uint)
+	case uint8:
+		return typed < second.(uint8)
+	case uint16:
+		return typed < second.(uint16)
+	case uint32:
+		return typed < second.(uint32)
+	case uint64:
+		return typed < second.(uint64)
+	case float32:
+		return typed < second.(float32)
+	case float64:
+		return typed < second.(float64)
+	case string:
+		return typed < second.(string)
+	default:
+		panic(fmt.Sprintf("streams: cannot sort keys of type %T", first))
+	}
+}
+
+// externalSorter holds up to options.MaxInMemory elements in buffer,
+// spilling them to a sorted run in a temp file whenever it fills up
+type externalSorter struct {
+	less    Less
+	options SortOptions
+	buffer  []interface{}
+	runs    []*os.File
+}
+
+func (sorter *externalSorter) add(element interface{}) error {
+	sorter.buffer = append(sorter.buffer, element)
+	if len(sorter.buffer) < sorter.options.MaxInMemory {
+		return nil
+	}
+	return sorter.spill()
+}
+
+func (sorter *externalSorter) sortBuffer() {
+	sort.SliceStable(sorter.buffer, func(i, j int) bool {
+		return sorter.less(sorter.buffer[i], sorter.buffer[j])
+	})
+}
+
+func (sorter *externalSorter) spill() error {
+	sorter.sortBuffer()
+	file, err := os.CreateTemp(sorter.options.TempDir, "streams-sort-*")
+	if err != nil {
+		return err
+	}
+	sorter.runs = append(sorter.runs, file)
+
+	writer := bufio.NewWriter(file)
+	encoder := sorter.options.Codec.NewEncoder(writer)
+	for _, element := range sorter.buffer {
+		if err := encoder.Encode(element); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	sorter.buffer = sorter.buffer[:0]
+	return nil
+}
+
+// merge k-way merges the runs on disk and the buffer, calling emit with each
+// element in order until emit returns false
+func (sorter *externalSorter) merge(emit func(element interface{}) bool) error {
+	sorter.sortBuffer()
+	if len(sorter.runs) == 0 {
+		for _, element := range sorter.buffer {
+			if !emit(element) {
+				return nil
+			}
+		}
+		return nil
+	}
+
+	heads := mergeHeap{less: sorter.less}
+	for index, file := range sorter.runs {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		decoder := sorter.options.Codec.NewDecoder(bufio.NewReader(file))
+		run := func() (interface{}, bool, error) {
+			element, err := decoder.Decode()
+			if err == io.EOF {
+				return nil, false, nil
+			}
+			return element, err == nil, err
+		}
+		if err := heads.pushNext(run, index); err != nil {
+			return err
+		}
+	}
+	remaining := sorter.buffer
+	inMemory := func() (interface{}, bool, error) {
+		if len(remaining) == 0 {
+			return nil, false, nil
+		}
+		element := remaining[0]
+		remaining = remaining[1:]
+		return element, true, nil
+	}
+	if err := heads.pushNext(inMemory, len(sorter.runs)); err != nil {
+		return err
+	}
+
+	for heads.Len() > 0 {
+		head := heap.Pop(&heads).(mergeHead)
+		if !emit(head.element) {
+			return nil
+		}
+		if err := heads.pushNext(head.run, head.index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sorter *externalSorter) cleanUp() {
+	for _, file := range sorter.runs {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+}
+
+// mergeHead is the smallest element of a sorted run that has not been emitted yet.
+// index is the position of the run, and breaks ties so the sort stays stable.
+type mergeHead struct {
+	element interface{}
+	run     func() (interface{}, bool, error)
+	index   int
+}
+
+// mergeHeap is a container/heap of the heads of every sorted run
+type mergeHeap struct {
+	less  Less
+	heads []mergeHead
+}
+
+// pushNext reads the next element of run and pushes it, if there is one
+func (heads *mergeHeap) pushNext(run func() (interface{}, bool, error), index int) error {
+	element, ok, err := run()
+	if ok {
+		heap.Push(heads, mergeHead{element, run, index})
+	}
+	return err
+}
+
+func (heads *mergeHeap) Len() int {
+	return len(heads.heads)
+}
+
+func (heads *mergeHeap) Less(i, j int) bool {
+	first, second := heads.heads[i], heads.heads[j]
+	if heads.less(first.element, second.element) {
+		return true
+	}
+	return !heads.less(second.element, first.element) && first.index < second.index
+}
+
+func (heads *mergeHeap) Swap(i, j int) {
+	heads.heads[i], heads.heads[j] = heads.heads[j], heads.heads[i]
+}
+
+func (heads *mergeHeap) Push(head interface{}) {
+	heads.heads = append(heads.heads, head.(mergeHead))
+}
+
+func (heads *mergeHeap) Pop() interface{} {
+	last := heads.heads[len(heads.heads)-1]
+	heads.heads = heads.heads[:len(heads.heads)-1]
+	return last
+}
@@ -0,0 +1,144 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+func IntLess(first, second interface{}) bool {
+	return first.(int) < second.(int)
+}
+
+type SortedCase struct {
+	Start       []interface{}
+	MaxInMemory int
+	Expected    []interface{}
+}
+
+func TestStreams_SortedWith(t *testing.T) {
+	cases := []SortedCase{
+		{[]interface{}{}, 0, []interface{}{}},
+		{[]interface{}{3, 1, 2}, 0, []interface{}{1, 2, 3}},
+		{[]interface{}{3, 1, 2}, 1, []interface{}{1, 2, 3}},
+		{[]interface{}{5, 3, 9, 1, 1, 0, 7, 2}, 3, []interface{}{0, 1, 1, 2, 3, 5, 7, 9}},
+		{[]interface{}{5, 3, 9, 1, 1, 0, 7, 2}, 4, []interface{}{0, 1, 1, 2, 3, 5, 7, 9}},
+	}
+
+	for _, caze := range cases {
+		dir := t.TempDir()
+		actual, err := FromCollection(caze.Start).
+			SortedWith(IntLess, SortOptions{MaxInMemory: caze.MaxInMemory, TempDir: dir}).
+			CollectErr(collectors.NewSliceCollector())
+
+		assert.Nil(t, err)
+		assert.Equal(t, caze.Expected, actual)
+		runs, _ := os.ReadDir(dir)
+		assert.Empty(t, runs)
+	}
+}
+
+func TestStreams_Sorted(t *testing.T) {
+	actual := FromCollection([]interface{}{2, 3, 1}).
+		Sorted(IntLess).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{1, 2, 3}, actual)
+}
+
+func TestStreams_SortedBy(t *testing.T) {
+	cases := []SortedCase{
+		{[]interface{}{"bb", "a", "ccc", "dd"}, 0, []interface{}{"a", "bb", "dd", "ccc"}},
+		// spilling must not break the tie between bb and dd either
+		{[]interface{}{"bb", "a", "ccc", "dd"}, 1, []interface{}{"a", "bb", "dd", "ccc"}},
+	}
+	length := func(element interface{}) interface{} { return len(element.(string)) }
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			SortedBy(length).
+			Collect(collectors.NewSliceCollector())
+		spilled := FromCollection(caze.Start).
+			SortedWith(func(first, second interface{}) bool {
+				return naturalLess(length(first), length(second))
+			}, SortOptions{MaxInMemory: caze.MaxInMemory, TempDir: t.TempDir()}).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+		assert.Equal(t, caze.Expected, spilled)
+	}
+}
+
+// LineCodec writes string elements one per line
+type LineCodec struct{}
+
+type lineEncoder struct {
+	writer io.Writer
+}
+
+type lineDecoder struct {
+	reader io.Reader
+}
+
+func (LineCodec) NewEncoder(writer io.Writer) SpillEncoder {
+	return lineEncoder{writer}
+}
+
+func (LineCodec) NewDecoder(reader io.Reader) SpillDecoder {
+	return lineDecoder{reader}
+}
+
+func (encoder lineEncoder) Encode(element interface{}) error {
+	_, err := fmt.Fprintln(encoder.writer, element)
+	return err
+}
+
+func (decoder lineDecoder) Decode() (interface{}, error) {
+	var line string
+	_, err := fmt.Fscanln(decoder.reader, &line)
+	return line, err
+}
+
+func TestStreams_SortedWithCodec(t *testing.T) {
+	actual := FromCollection([]interface{}{"d", "b", "a", "c", "e"}).
+		SortedWith(func(first, second interface{}) bool {
+			return strings.Compare(first.(string), second.(string)) < 0
+		}, SortOptions{MaxInMemory: 2, Codec: LineCodec{}, TempDir: t.TempDir()}).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{"a", "b", "c", "d", "e"}, actual)
+}
+
+type FailingCodec struct{}
+
+type failingEncoder struct{}
+
+func (FailingCodec) NewEncoder(_ io.Writer) SpillEncoder {
+	return failingEncoder{}
+}
+
+func (FailingCodec) NewDecoder(_ io.Reader) SpillDecoder {
+	return nil
+}
+
+func (failingEncoder) Encode(_ interface{}) error {
+	return errors.New("cannot encode")
+}
+
+func TestStreams_SortedWithSpillError(t *testing.T) {
+	_, err := FromCollection([]interface{}{3, 2, 1}).
+		SortedWith(IntLess, SortOptions{MaxInMemory: 1, Codec: FailingCodec{}, TempDir: t.TempDir()}).
+		CollectErr(collectors.NewSliceCollector())
+
+	assert.EqualError(t, err, "cannot encode")
+}
+
+func TestNaturalLessPanicsOnUnknownTypes(t *testing.T) {
+	assert.Panics(t, func() { naturalLess([]int{}, []int{}) })
+}
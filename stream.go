@@ -2,6 +2,8 @@ package streams
 
 import (
 	"bufio"
+	"context"
+	"sync"
 )
 
 // Predicate is used to Filter elements from streams
@@ -20,14 +22,45 @@ type FlatMapper func(element interface{}) []interface{}
 // Consumer is used to perform some process ForEach element in streams
 type Consumer func(element interface{})
 
+// PredicateErr is a Predicate that can fail. It is used by FilterErr
+type PredicateErr func(element interface{}) (bool, error)
+
+// MapperErr is a Mapper that can fail. It is used by MapErr
+type MapperErr func(element interface{}) (interface{}, error)
+
+// ConsumerErr is a Consumer that can fail. It is used by ForEachErr
+type ConsumerErr func(element interface{}) error
+
 // Collector is used by streams.Collect
 // Add is used to add stream elements to the collection
 // Complete returns the collection
-type Collector interface {
+// It is an alias of an unnamed interface type, so packages like collectors
+// can implement ConcurrentCollector without importing this package.
+type Collector = interface {
 	Add(subject interface{})
 	Complete() interface{}
 }
 
+// ErrCollector is a Collector that can fail, like a collector that does not
+// allow duplicates. CompleteErr returns the same collection as Complete along
+// with the error, if any; CollectErr returns that error.
+type ErrCollector interface {
+	Collector
+	CompleteErr() (interface{}, error)
+}
+
+// ConcurrentCollector is a Collector that streams.ParallelCollect can
+// split across goroutines.
+// Supplier returns a new, empty collector of the same kind, which will
+// only be used by one goroutine.
+// Combine adds everything collected by other, which came from Supplier,
+// to the collector.
+type ConcurrentCollector interface {
+	Collector
+	Supplier() Collector
+	Combine(other Collector)
+}
+
 // Stream is the underlying data type that the Streams struct uses
 type Stream chan interface{}
 
@@ -36,22 +69,134 @@ type Stream chan interface{}
 // Filter / Map / ForEachThen that has been called on this
 // Streams object thus far. Each will have a buffer of size
 // channelBuffer.
+// ctx is handed to every stage created from here on; cancelling it
+// makes those stages stop and close their Stream. stoppers are stopped
+// once a stage fails or a terminal operation finishes, which makes
+// every stage stop regardless of which ctx it was created with.
+// Stages like Limit start a new stopper so they can stop the stages
+// in front of them without stopping the ones after them.
 type Streams struct {
 	streams       []Stream
 	channelBuffer int
+	ctx           context.Context
+	err           error
+
+	mutex    sync.Mutex
+	stoppers []*stopper
+	failure  error
 }
 
-func toStream(collection []interface{}) Stream {
-	ch := make(chan interface{}, len(collection))
+// stopper is closed to tell a group of stages to stop
+type stopper struct {
+	stop chan struct{}
+	once sync.Once
+}
 
-	go func() {
-		defer close(ch)
-		for _, element := range collection {
-			ch <- element
-		}
-	}()
+func newStopper() *stopper {
+	return &stopper{stop: make(chan struct{})}
+}
+
+// halt closes the stop channel. It is safe to call more than once.
+func (stopper *stopper) halt() {
+	stopper.once.Do(func() {
+		close(stopper.stop)
+	})
+}
+
+// lifetime is what a stage goroutine watches to know when to give up early
+type lifetime struct {
+	ctx  context.Context
+	stop <-chan struct{}
+}
+
+// send puts element on stream, giving up if the stage is stopped first.
+// Returns false if the element was not sent.
+func (life lifetime) send(stream Stream, element interface{}) bool {
+	select {
+	case stream <- element:
+		return true
+	case <-life.ctx.Done():
+		return false
+	case <-life.stop:
+		return false
+	}
+}
+
+// receive takes the next element off stream, giving up if the stage is stopped first.
+// Returns false if the stream is closed or the stage was stopped.
+func (life lifetime) receive(stream Stream) (interface{}, bool) {
+	select {
+	case element, ok := <-stream:
+		return element, ok
+	case <-life.ctx.Done():
+		return nil, false
+	case <-life.stop:
+		return nil, false
+	}
+}
+
+// stopped returns true once the stage has been told to stop
+func (life lifetime) stopped() bool {
+	select {
+	case <-life.ctx.Done():
+		return true
+	case <-life.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (streams *Streams) lifetime() lifetime {
+	streams.mutex.Lock()
+	defer streams.mutex.Unlock()
+	return lifetime{streams.ctx, streams.stoppers[len(streams.stoppers)-1].stop}
+}
+
+// halt stops every stage of the streams. It is safe to call more than once.
+func (streams *Streams) halt() {
+	streams.mutex.Lock()
+	defer streams.mutex.Unlock()
+	for _, stopper := range streams.stoppers {
+		stopper.halt()
+	}
+}
+
+// splitLifetime starts a new group of stages. The returned function stops
+// every stage added before the split, and the returned lifetime belongs to
+// the stage being added, which is the first stage of the new group.
+func (streams *Streams) splitLifetime() (lifetime, func()) {
+	streams.mutex.Lock()
+	upstream := streams.stoppers[len(streams.stoppers)-1]
+	streams.stoppers = append(streams.stoppers, newStopper())
+	streams.mutex.Unlock()
+	return streams.lifetime(), upstream.halt
+}
+
+// fail records err as the reason the streams stopped, then halts them.
+// Only the first error is kept.
+func (streams *Streams) fail(err error) {
+	streams.mutex.Lock()
+	if streams.failure == nil {
+		streams.failure = err
+	}
+	streams.mutex.Unlock()
+	streams.halt()
+}
 
-	return ch
+func (streams *Streams) firstFailure() error {
+	streams.mutex.Lock()
+	defer streams.mutex.Unlock()
+	return streams.failure
+}
+
+func fillStream(life lifetime, stream Stream, collection []interface{}) {
+	defer close(stream)
+	for _, element := range collection {
+		if !life.send(stream, element) {
+			return
+		}
+	}
 }
 
 // FromCollection creates a streams object from the given slice.
@@ -59,15 +204,29 @@ func toStream(collection []interface{}) Stream {
 // If the data being processed is large enough that a slice would be
 // impractical, use FromStream instead
 func FromCollection(collection []interface{}) *Streams {
-	startStream := toStream(collection)
-	return FromStream(startStream, len(collection))
+	return FromCollectionContext(context.Background(), collection)
+}
+
+// FromCollectionContext is FromCollection, but every goroutine the
+// streams object starts, including the one feeding it the collection,
+// exits once ctx is cancelled.
+func FromCollectionContext(ctx context.Context, collection []interface{}) *Streams {
+	startStream := make(Stream, len(collection))
+	streams := FromStream(startStream, len(collection)).WithContext(ctx)
+	go fillStream(streams.lifetime(), startStream, collection)
+	return streams
 }
 
 // FromStream creates a streams object from the given channel
 // Future Stream objects in the streams object will be created with
 // a buffer size of bufferSize
 func FromStream(stream Stream, bufferSize int) *Streams {
-	streams := Streams{[]Stream{stream}, bufferSize}
+	streams := Streams{
+		streams:       []Stream{stream},
+		channelBuffer: bufferSize,
+		ctx:           context.Background(),
+		stoppers:      []*stopper{newStopper()},
+	}
 	return &streams
 }
 
@@ -77,20 +236,46 @@ func FromStream(stream Stream, bufferSize int) *Streams {
 // Future Stream objects in the streams object will be created with
 // a buffer size of bufferSize
 func FromScanner(scanner *bufio.Scanner, bufferSize int) *Streams {
+	return FromScannerContext(context.Background(), scanner, bufferSize)
+}
+
+// FromScannerContext is FromScanner, but the goroutine reading from the
+// scanner stops scanning once ctx is cancelled, as does every stage
+// added to the streams object.
+func FromScannerContext(ctx context.Context, scanner *bufio.Scanner, bufferSize int) *Streams {
 	ch := make(Stream, bufferSize)
+	streams := FromStream(ch, bufferSize).WithContext(ctx)
+	life := streams.lifetime()
 
 	go func() {
 		defer close(ch)
 		for scanner.Scan() {
-			ch <- scanner.Text()
+			if !life.send(ch, scanner.Text()) {
+				return
+			}
 		}
 	}()
 
-	streams := Streams{
-		[]Stream{ch},
-		bufferSize,
-	}
-	return &streams
+	return streams
+}
+
+// WithContext sets the context used by every stage and terminal operation
+// added to the streams after this call. Once ctx is cancelled, those stages
+// stop, close their Stream and let their goroutines exit, and terminal
+// operations return early; Err then reports why.
+// Stages added before WithContext keep the context they were created with,
+// so call it right after creating the streams, or use FromCollectionContext
+// or FromScannerContext to cover the source goroutine as well.
+func (streams *Streams) WithContext(ctx context.Context) *Streams {
+	streams.ctx = ctx
+	return streams
+}
+
+// Err returns the reason the last terminal operation stopped early,
+// or nil if it consumed the whole stream. This is either the first error
+// returned by a MapErr / FilterErr stage or ForEachErr consumer, or ctx.Err().
+func (streams *Streams) Err() error {
+	return streams.err
 }
 
 func addNewStream(streams *Streams) (current, next Stream) {
@@ -105,12 +290,17 @@ func addNewStream(streams *Streams) (current, next Stream) {
 // elements that cause the Predicate to evaluate to false are discarded.
 func (streams *Streams) Filter(predicate Predicate) *Streams {
 	current, next := addNewStream(streams)
+	life := streams.lifetime()
 
 	go func() {
 		defer close(next)
-		for object := range current {
-			if predicate(object) {
-				next <- object
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			if predicate(object) && !life.send(next, object) {
+				return
 			}
 		}
 	}()
@@ -122,11 +312,15 @@ func (streams *Streams) Filter(predicate Predicate) *Streams {
 // Use this to turn the elements of the stream from one thing into another thing
 func (streams *Streams) Map(mapper Mapper) *Streams {
 	current, next := addNewStream(streams)
+	life := streams.lifetime()
 
 	go func() {
 		defer close(next)
-		for object := range current {
-			next <- mapper(object)
+		for {
+			object, ok := life.receive(current)
+			if !ok || !life.send(next, mapper(object)) {
+				return
+			}
 		}
 	}()
 
@@ -137,12 +331,75 @@ func (streams *Streams) Map(mapper Mapper) *Streams {
 // FlatMapper. Use this to turn each element in a stream into 0 or more elements.
 func (streams *Streams) FlatMap(mapper FlatMapper) *Streams {
 	current, next := addNewStream(streams)
+	life := streams.lifetime()
 
 	go func() {
 		defer close(next)
-		for object := range current {
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
 			for _, element := range mapper(object) {
-				next <- element
+				if !life.send(next, element) {
+					return
+				}
+			}
+		}
+	}()
+
+	return streams
+}
+
+// MapErr is Map for Mappers that can fail. The first error returned by
+// mapper stops every stage of the streams, and is reported by Err and by
+// the error forms of the terminal operations, like ReduceErr.
+func (streams *Streams) MapErr(mapper MapperErr) *Streams {
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			mapped, err := mapper(object)
+			if err != nil {
+				streams.fail(err)
+				return
+			}
+			if !life.send(next, mapped) {
+				return
+			}
+		}
+	}()
+
+	return streams
+}
+
+// FilterErr is Filter for Predicates that can fail. The first error returned by
+// predicate stops every stage of the streams, and is reported by Err and by
+// the error forms of the terminal operations, like ReduceErr.
+func (streams *Streams) FilterErr(predicate PredicateErr) *Streams {
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+
+	go func() {
+		defer close(next)
+		for {
+			object, ok := life.receive(current)
+			if !ok {
+				return
+			}
+			keep, err := predicate(object)
+			if err != nil {
+				streams.fail(err)
+				return
+			}
+			if keep && !life.send(next, object) {
+				return
 			}
 		}
 	}()
@@ -160,31 +417,108 @@ func (streams *Streams) lastStream() Stream {
 // will be ugly.
 // The first parameter will always be the reduction thus far, and for the first
 // iteration, it will be the initial parameter passed into Reduce
+// If the streams stop early, the reduction thus far is returned and Err
+// reports why.
 func (streams *Streams) Reduce(initial interface{}, reducer Reducer) interface{} {
-	for element := range streams.lastStream() {
+	reduction, _ := streams.ReduceErr(initial, reducer)
+	return reduction
+}
+
+// ReduceErr is Reduce, but it also returns the error that stopped the
+// streams early, if any. See Err.
+func (streams *Streams) ReduceErr(initial interface{}, reducer Reducer) (interface{}, error) {
+	err := streams.each(func(element interface{}) error {
 		initial = reducer(initial, element)
-	}
-	return initial
+		return nil
+	})
+	return initial, err
 }
 
 // Collect the elements in the stream in a single collection like a slice or
 // a map. This function is out of place because it accepts an interface
 // instead of a function.
 // Calls Collector.Add on each element of the stream, then returns Collector.Complete
+// If the streams stop early, Collector.Complete is returned with whatever
+// was added thus far and Err reports why.
 func (streams *Streams) Collect(collector Collector) interface{} {
-	lastStream := streams.lastStream()
-	for element := range lastStream {
+	collection, _ := streams.CollectErr(collector)
+	return collection
+}
+
+// CollectErr is Collect, but it also returns the error that stopped the
+// streams early, if any. See Err. If collector is an ErrCollector, the error
+// from CompleteErr is returned as well, and reported by Err.
+func (streams *Streams) CollectErr(collector Collector) (interface{}, error) {
+	err := streams.each(func(element interface{}) error {
 		collector.Add(element)
+		return nil
+	})
+	return streams.complete(collector, err)
+}
+
+// complete completes collector, passing on err, the error that stopped the
+// streams, unless it is nil and collector is an ErrCollector that failed.
+func (streams *Streams) complete(collector Collector, err error) (interface{}, error) {
+	errCollector, ok := collector.(ErrCollector)
+	if !ok {
+		return collector.Complete(), err
 	}
-	return collector.Complete()
+	collection, completeErr := errCollector.CompleteErr()
+	if err == nil {
+		err = completeErr
+		streams.err = err
+	}
+	return collection, err
 }
 
 // ForEach calls consumer(element) on each element on the stream
+// If the streams stop early, ForEach returns early and Err reports why.
 func (streams *Streams) ForEach(consumer Consumer) {
-	lastStream := streams.lastStream()
-	for element := range lastStream {
+	_ = streams.ForEachErr(func(element interface{}) error {
 		consumer(element)
+		return nil
+	})
+}
+
+// ForEachErr calls consumer(element) on each element on the stream until
+// consumer returns an error. That error stops every stage of the streams
+// and is returned. Errors from MapErr / FilterErr stages and ctx.Err()
+// are returned as well. See Err.
+func (streams *Streams) ForEachErr(consumer ConsumerErr) error {
+	return streams.each(consumer)
+}
+
+// each drains the last stream into consumer on the calling goroutine, stopping
+// early if the context is cancelled, a stage fails or consumer returns an error.
+// Once done, every stage is halted and Err is set.
+func (streams *Streams) each(consumer ConsumerErr) error {
+	life := streams.lifetime()
+	lastStream := streams.lastStream()
+	defer streams.halt()
+
+	for {
+		element, ok := life.receive(lastStream)
+		if !ok {
+			break
+		}
+		if err := consumer(element); err == errShortCircuit {
+			break
+		} else if err != nil {
+			streams.fail(err)
+			break
+		}
 	}
+
+	return streams.finish(life)
+}
+
+// finish records why a terminal operation ended, for Err, and returns it
+func (streams *Streams) finish(life lifetime) error {
+	streams.err = streams.firstFailure()
+	if streams.err == nil {
+		streams.err = life.ctx.Err()
+	}
+	return streams.err
 }
 
 // ForEachThen calls consumer(element) on each element of the stream, returns the streams
@@ -194,12 +528,19 @@ func (streams *Streams) ForEach(consumer Consumer) {
 // That said, this can be more readable, and it allows you to Collect / Reduce after
 func (streams *Streams) ForEachThen(consumer Consumer) *Streams {
 	current, next := addNewStream(streams)
+	life := streams.lifetime()
 
 	go func() {
 		defer close(next)
-		for element := range current {
+		for {
+			element, ok := life.receive(current)
+			if !ok {
+				return
+			}
 			consumer(element)
-			next <- element
+			if !life.send(next, element) {
+				return
+			}
 		}
 	}()
 
@@ -2,10 +2,13 @@ package streams
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/Luke-Sikina/streams/collectors"
 )
@@ -304,3 +307,186 @@ func TestStreams_FlatMap(t *testing.T) {
 		assert.Equal(t, caze.Expected, actual)
 	}
 }
+
+func TestFromCollectionContext(t *testing.T) {
+	cases := [][]interface{}{
+		{},
+		{1, 2, 3},
+	}
+
+	for _, caze := range cases {
+		stream := FromCollectionContext(context.Background(), caze)
+		actual := stream.Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze, actual)
+		assert.Nil(t, stream.Err())
+	}
+}
+
+// source never closes, so without cancellation every stage would block forever
+func TestStreams_WithContextCancelStopsTerminal(t *testing.T) {
+	source := make(Stream)
+	ctx, cancel := context.WithCancel(context.Background())
+	seen := 0
+
+	stream := FromStream(source, 0).
+		WithContext(ctx).
+		Map(MapDoubleVal).
+		Filter(AcceptAllPredicate).
+		FlatMap(func(element interface{}) []interface{} { return []interface{}{element} }).
+		ForEachThen(func(_ interface{}) {})
+
+	go func() {
+		source <- 1
+		source <- 2
+		cancel()
+	}()
+	stream.ForEach(func(_ interface{}) { seen++ })
+
+	assert.True(t, seen <= 2)
+	assert.Equal(t, context.Canceled, stream.Err())
+}
+
+func TestStreams_WithContextCancelClosesStages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := TestReader{[][]byte{[]byte("a\n"), []byte("b\n"), []byte("c\n")}, 0}
+
+	stream := FromScannerContext(ctx, bufio.NewScanner(&reader), 0).
+		Map(func(element interface{}) interface{} { return element }).
+		Filter(AcceptAllPredicate)
+	cancel()
+
+	// every stage closes its Stream once cancelled, even though no terminal ran
+	for _, stage := range stream.streams {
+		for range stage {
+		}
+	}
+}
+
+var errTest = errors.New("test error")
+
+func FailOnThree(element interface{}) (interface{}, error) {
+	if element.(int) == 3 {
+		return nil, errTest
+	}
+	return element, nil
+}
+
+func FailOnThreePredicate(element interface{}) (bool, error) {
+	if element.(int) == 3 {
+		return false, errTest
+	}
+	return true, nil
+}
+
+type StreamsErrCase struct {
+	Start    []interface{}
+	Expected []interface{}
+	Err      error
+}
+
+func TestStreams_MapErr(t *testing.T) {
+	cases := []StreamsErrCase{
+		{[]interface{}{}, []interface{}{}, nil},
+		{[]interface{}{1, 2}, []interface{}{1, 2}, nil},
+		{[]interface{}{1, 2, 3, 4}, nil, errTest},
+	}
+
+	for _, caze := range cases {
+		stream := FromCollection(caze.Start).MapErr(FailOnThree)
+		actual, err := stream.CollectErr(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Err, err)
+		assert.Equal(t, caze.Err, stream.Err())
+		if caze.Err == nil {
+			assert.Equal(t, caze.Expected, actual)
+		}
+	}
+}
+
+func TestStreams_FilterErr(t *testing.T) {
+	cases := []StreamsErrCase{
+		{[]interface{}{}, []interface{}{}, nil},
+		{[]interface{}{1, 2}, []interface{}{1, 2}, nil},
+		{[]interface{}{1, 2, 3, 4}, nil, errTest},
+	}
+
+	for _, caze := range cases {
+		actual, err := FromCollection(caze.Start).
+			FilterErr(FailOnThreePredicate).
+			CollectErr(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Err, err)
+		if caze.Err == nil {
+			assert.Equal(t, caze.Expected, actual)
+		}
+	}
+}
+
+// rejectingCollector is an ErrCollector that fails when it sees 3
+type rejectingCollector struct {
+	sliceCollector
+	err error
+}
+
+func (collector *rejectingCollector) Add(subject interface{}) {
+	if subject == 3 {
+		collector.err = errTest
+	}
+	collector.sliceCollector.Add(subject)
+}
+
+func (collector *rejectingCollector) CompleteErr() (interface{}, error) {
+	return collector.collection, collector.err
+}
+
+func TestStreams_CollectErr_ErrCollector(t *testing.T) {
+	cases := []StreamsErrCase{
+		{[]interface{}{1, 2}, []interface{}{1, 2}, nil},
+		{[]interface{}{1, 2, 3, 4}, []interface{}{1, 2, 3, 4}, errTest},
+	}
+
+	for _, caze := range cases {
+		stream := FromCollection(caze.Start)
+		actual, err := stream.CollectErr(&rejectingCollector{sliceCollector: sliceCollector{[]interface{}{}}})
+
+		assert.Equal(t, caze.Err, err)
+		assert.Equal(t, caze.Err, stream.Err())
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+func TestStreams_ForEachErr(t *testing.T) {
+	seen := 0
+	err := FromCollection([]interface{}{1, 2, 3, 4}).
+		ForEachErr(func(element interface{}) error {
+			seen++
+			_, err := FailOnThree(element)
+			return err
+		})
+
+	assert.Equal(t, errTest, err)
+	assert.Equal(t, 3, seen)
+}
+
+// a failing stage has to stop the stages in front of it, or the
+// never ending source below would block ReduceErr forever
+func TestStreams_MapErrStopsUpstream(t *testing.T) {
+	source := make(Stream)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case source <- i:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	_, err := FromStream(source, 0).
+		Map(func(element interface{}) interface{} { return element }).
+		MapErr(FailOnThree).
+		ReduceErr(0, ReduceToSum)
+
+	assert.Equal(t, errTest, err)
+}
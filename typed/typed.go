@@ -0,0 +1,190 @@
+// Package typed is a generics based, type safe API on top of streams.Streams.
+// Every stage is a thin wrapper around the matching streams.Streams stage, so
+// a Stream can be converted to and from a *streams.Streams at any point in a
+// pipeline, which makes it possible to migrate one stage at a time.
+package typed
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/Luke-Sikina/streams"
+)
+
+// Collector is the type safe version of streams.Collector.
+// Add is used to add stream elements to the collection
+// Complete returns the collection
+type Collector[T, R any] interface {
+	Add(element T)
+	Complete() R
+}
+
+// Stream is a streams.Streams whose elements are all of type T
+type Stream[T any] struct {
+	streams *streams.Streams
+}
+
+// as converts an element of the underlying streams.Streams to T.
+// nil becomes the zero value of T so that streams of interfaces,
+// pointers and slices can carry nil elements.
+func as[T any](element interface{}) T {
+	if element == nil {
+		var zero T
+		return zero
+	}
+	return element.(T)
+}
+
+// FromCollection creates a Stream from the given slice.
+// See streams.FromCollection
+func FromCollection[T any](collection []T) *Stream[T] {
+	return FromCollectionContext(context.Background(), collection)
+}
+
+// FromCollectionContext creates a Stream from the given slice that stops
+// once ctx is cancelled. See streams.FromCollectionContext
+func FromCollectionContext[T any](ctx context.Context, collection []T) *Stream[T] {
+	elements := make([]interface{}, len(collection))
+	for index, element := range collection {
+		elements[index] = element
+	}
+	return FromStreams[T](streams.FromCollectionContext(ctx, elements))
+}
+
+// FromScanner creates a Stream of the lines of the scanner.
+// See streams.FromScanner
+func FromScanner(scanner *bufio.Scanner, bufferSize int) *Stream[string] {
+	return FromStreams[string](streams.FromScanner(scanner, bufferSize))
+}
+
+// FromStreams wraps an existing streams.Streams. Every element of the streams
+// must be of type T (or nil); elements of any other type cause a panic in the
+// stage that first uses them.
+func FromStreams[T any](s *streams.Streams) *Stream[T] {
+	return &Stream[T]{s}
+}
+
+// Streams returns the underlying streams.Streams so that untyped stages can
+// be added to the pipeline. Stages added this way are shared with the Stream.
+func (stream *Stream[T]) Streams() *streams.Streams {
+	return stream.streams
+}
+
+// WithContext sets the context used by every stage added after this call.
+// See streams.Streams.WithContext
+func (stream *Stream[T]) WithContext(ctx context.Context) *Stream[T] {
+	stream.streams.WithContext(ctx)
+	return stream
+}
+
+// Err returns the reason the last terminal operation stopped early.
+// See streams.Streams.Err
+func (stream *Stream[T]) Err() error {
+	return stream.streams.Err()
+}
+
+// Filter asynchronously filters the elements in the stream using predicate.
+// Elements for which predicate returns true are kept.
+func (stream *Stream[T]) Filter(predicate func(element T) bool) *Stream[T] {
+	stream.streams.Filter(func(element interface{}) bool {
+		return predicate(as[T](element))
+	})
+	return stream
+}
+
+// ForEachThen calls consumer on each element of the stream, returning the
+// stream for future use.
+func (stream *Stream[T]) ForEachThen(consumer func(element T)) *Stream[T] {
+	stream.streams.ForEachThen(func(element interface{}) {
+		consumer(as[T](element))
+	})
+	return stream
+}
+
+// ForEach calls consumer on each element of the stream
+func (stream *Stream[T]) ForEach(consumer func(element T)) {
+	stream.streams.ForEach(func(element interface{}) {
+		consumer(as[T](element))
+	})
+}
+
+// Map asynchronously transforms each element of stream from a T to an R.
+// This is a function rather than a method because methods cannot introduce
+// new type parameters.
+func Map[T, R any](stream *Stream[T], mapper func(element T) R) *Stream[R] {
+	stream.streams.Map(func(element interface{}) interface{} {
+		return mapper(as[T](element))
+	})
+	return &Stream[R]{stream.streams}
+}
+
+// FlatMap asynchronously transforms each element of stream into 0 or more
+// elements of type R, which are then flattened into the resulting Stream.
+func FlatMap[T, R any](stream *Stream[T], mapper func(element T) []R) *Stream[R] {
+	stream.streams.FlatMap(func(element interface{}) []interface{} {
+		mapped := mapper(as[T](element))
+		flattened := make([]interface{}, len(mapped))
+		for index, element := range mapped {
+			flattened[index] = element
+		}
+		return flattened
+	})
+	return &Stream[R]{stream.streams}
+}
+
+// Reduce the elements of stream to a single value of type A.
+// The first parameter of reducer is always the reduction thus far,
+// starting with initial.
+func Reduce[T, A any](stream *Stream[T], initial A, reducer func(reduction A, element T) A) A {
+	stream.streams.ForEach(func(element interface{}) {
+		initial = reducer(initial, as[T](element))
+	})
+	return initial
+}
+
+// Collect the elements of stream using collector and return the result
+// of collector.Complete
+func Collect[T, R any](stream *Stream[T], collector Collector[T, R]) R {
+	stream.ForEach(collector.Add)
+	return collector.Complete()
+}
+
+// SliceCollector collects the elements of the stream in a []T
+type SliceCollector[T any] struct {
+	collection []T
+}
+
+// NewSliceCollector creates a SliceCollector with an empty
+// slice and returns a pointer to it.
+func NewSliceCollector[T any]() *SliceCollector[T] {
+	return &SliceCollector[T]{[]T{}}
+}
+
+// Add adds the element to the slice
+func (collector *SliceCollector[T]) Add(element T) {
+	collector.collection = append(collector.collection, element)
+}
+
+// Complete returns the slice that Add has been populating
+func (collector *SliceCollector[T]) Complete() []T {
+	return collector.collection
+}
+
+// FromCollector adapts an untyped streams.Collector, such as the ones in
+// the collectors package, for use with Collect. The result of Complete is
+// asserted to be an R.
+func FromCollector[T, R any](collector streams.Collector) Collector[T, R] {
+	return untypedCollector[T, R]{collector}
+}
+
+type untypedCollector[T, R any] struct {
+	collector streams.Collector
+}
+
+func (collector untypedCollector[T, R]) Add(element T) {
+	collector.collector.Add(element)
+}
+
+func (collector untypedCollector[T, R]) Complete() R {
+	return as[R](collector.collector.Complete())
+}
@@ -0,0 +1,112 @@
+package typed
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Luke-Sikina/streams"
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromCollection(t *testing.T) {
+	cases := [][]int{
+		{},
+		{1, 2, 3},
+	}
+
+	for _, caze := range cases {
+		actual := Collect[int, []int](FromCollection(caze), NewSliceCollector[int]())
+
+		assert.Equal(t, caze, actual)
+	}
+}
+
+func TestFromScanner(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("1\n2\n3"))
+
+	actual := Collect[string, []string](FromScanner(scanner, 10), NewSliceCollector[string]())
+
+	assert.Equal(t, []string{"1", "2", "3"}, actual)
+}
+
+func TestStream_Filter(t *testing.T) {
+	actual := Collect[int, []int](
+		FromCollection([]int{1, 2, 3, 4}).Filter(func(e int) bool { return e%2 == 0 }),
+		NewSliceCollector[int]())
+
+	assert.Equal(t, []int{2, 4}, actual)
+}
+
+func TestMap(t *testing.T) {
+	ints := Map(FromCollection([]string{"1", "2", "x"}), func(e string) int {
+		asInt, _ := strconv.Atoi(e)
+		return asInt
+	})
+	actual := Collect[int, []int](ints, NewSliceCollector[int]())
+
+	assert.Equal(t, []int{1, 2, 0}, actual)
+}
+
+func TestFlatMap(t *testing.T) {
+	counted := FlatMap(FromCollection([]int{0, 1, 2}), func(e int) []string {
+		return strings.Split(strings.Repeat("a", e), "")
+	})
+	actual := Collect[string, []string](counted, NewSliceCollector[string]())
+
+	assert.Equal(t, []string{"a", "a", "a"}, actual)
+}
+
+func TestReduce(t *testing.T) {
+	actual := Reduce(FromCollection([]int{1, 2, 3}), "", func(reduction string, e int) string {
+		return reduction + strconv.Itoa(e)
+	})
+
+	assert.Equal(t, "123", actual)
+}
+
+func TestStream_ForEachThen(t *testing.T) {
+	seen := []int{}
+	actual := Collect[int, []int](
+		FromCollection([]int{1, 2, 3}).ForEachThen(func(e int) { seen = append(seen, e) }),
+		NewSliceCollector[int]())
+
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.Equal(t, []int{1, 2, 3}, actual)
+}
+
+func TestNilElements(t *testing.T) {
+	actual := Collect[error, []error](FromCollection([]error{nil, nil}), NewSliceCollector[error]())
+
+	assert.Equal(t, []error{nil, nil}, actual)
+}
+
+func TestAdapters(t *testing.T) {
+	untyped := streams.FromCollection([]interface{}{1, 2, 3})
+	typed := Map(FromStreams[int](untyped), func(e int) int { return e * 2 })
+	actual := typed.Streams().
+		Filter(func(e interface{}) bool { return e.(int) > 2 }).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{4, 6}, actual)
+}
+
+func TestFromCollector(t *testing.T) {
+	actual := Collect(FromCollection([]int{1, 2}), FromCollector[int, []interface{}](collectors.NewSliceCollector()))
+
+	assert.Equal(t, []interface{}{1, 2}, actual)
+}
+
+func TestStream_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := FromStreams[int](streams.FromStream(make(streams.Stream), 0)).WithContext(ctx)
+
+	actual := Collect[int, []int](stream, NewSliceCollector[int]())
+
+	assert.Equal(t, []int{}, actual)
+	assert.Equal(t, context.Canceled, stream.Err())
+}
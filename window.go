@@ -0,0 +1,352 @@
+package streams
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Timestamper returns the event time of an element. It is used by the
+// windowing stages; passing nil instead means processing time, where each
+// element is stamped with the time it reached the stage.
+type Timestamper func(element interface{}) time.Time
+
+// Window is an element emitted by the windowing stages once a window closes.
+// Key is the session key for SessionWindow, and nil otherwise.
+// Contents is the []interface{} of the elements in the window, or the result
+// of Complete if WindowOptions.NewCollector was set.
+type Window struct {
+	Key        interface{}
+	Start, End time.Time
+	Contents   interface{}
+}
+
+// WindowOptions configures the windowing stages. The zero value of each
+// field means the default.
+// AllowedLateness is how far behind the latest event time an element can be
+// and still be added to its window, which is how long windows are held open
+// after their end. Elements later than that are discarded. Defaults to 0.
+// NewCollector creates a Collector for each window, which receives the
+// window's elements. Defaults to collecting them into a []interface{}.
+type WindowOptions struct {
+	AllowedLateness time.Duration
+	NewCollector    func() Collector
+}
+
+// TumblingWindow groups the elements of the streams into back to back windows
+// of length size, by their timestamp. size must be positive. Each window is
+// emitted as a Window once it closes. Pass nil for timestamp to use processing
+// time, in which case windows close on the clock even if no more elements
+// arrive. With event time, windows close once an element with a later enough
+// timestamp arrives, or the streams end.
+func (streams *Streams) TumblingWindow(size time.Duration, timestamp Timestamper) *Streams {
+	return streams.TumblingWindowWith(size, timestamp, WindowOptions{})
+}
+
+// TumblingWindowWith is TumblingWindow configured with options
+func (streams *Streams) TumblingWindowWith(size time.Duration, timestamp Timestamper, options WindowOptions) *Streams {
+	return streams.SlidingWindowWith(size, size, timestamp, options)
+}
+
+// SlidingWindow groups the elements of the streams into windows of length size
+// that start every slide, by their timestamp. If slide is less than size, the
+// windows overlap and an element can be in more than one of them.
+// See TumblingWindow for how windows close.
+func (streams *Streams) SlidingWindow(size, slide time.Duration, timestamp Timestamper) *Streams {
+	return streams.SlidingWindowWith(size, slide, timestamp, WindowOptions{})
+}
+
+// SlidingWindowWith is SlidingWindow configured with options
+func (streams *Streams) SlidingWindowWith(size, slide time.Duration, timestamp Timestamper, options WindowOptions) *Streams {
+	if size <= 0 || slide <= 0 {
+		panic("streams: non-positive window size or slide")
+	}
+	windower := newWindower(timestamp, options)
+	windower.add = func(key, element interface{}, at time.Time) {
+		// the latest window holding at starts at or before at, the earliest one
+		// ends after it
+		for start := at.Truncate(slide); start.Add(size).After(at); start = start.Add(-slide) {
+			windower.addToWindow(key, start, start.Add(size), element)
+		}
+	}
+	return streams.addWindowStage(windower)
+}
+
+// SessionWindow groups the elements of the streams with the same key into
+// sessions: windows that stay open until no element with that key has arrived
+// for gap. A session starts at its first element and ends gap after its last.
+// See TumblingWindow for how windows close.
+func (streams *Streams) SessionWindow(gap time.Duration, key Mapper, timestamp Timestamper) *Streams {
+	return streams.SessionWindowWith(gap, key, timestamp, WindowOptions{})
+}
+
+// SessionWindowWith is SessionWindow configured with options
+func (streams *Streams) SessionWindowWith(gap time.Duration, key Mapper, timestamp Timestamper, options WindowOptions) *Streams {
+	if gap <= 0 {
+		panic("streams: non-positive session gap")
+	}
+	windower := newWindower(timestamp, options)
+	windower.key = key
+	windower.add = func(key, element interface{}, at time.Time) {
+		windower.addToSession(key, at, at.Add(gap), element)
+	}
+	return streams.addWindowStage(windower)
+}
+
+// openWindow is a window that has not been emitted yet. Tumbling and sliding
+// windows add their elements to collector as they arrive; sessions keep them
+// in elements, as two sessions can be merged into one before they close.
+// index is the window's place in windowHeap.
+type openWindow struct {
+	key        interface{}
+	start, end time.Time
+	collector  Collector
+	elements   []interface{}
+	index      int
+}
+
+// windower holds the open windows of a windowing stage. watermark is the
+// latest event time minus the allowed lateness; any window that ends at or
+// before it is closed. Tumbling and sliding windows are found by their start
+// in starts, sessions by their key in sessions, and ends orders all of them
+// by when they close, so closing them does not look at the ones still open.
+type windower struct {
+	timestamp Timestamper
+	options   WindowOptions
+	key       Mapper
+	add       func(key, element interface{}, at time.Time)
+	starts    map[int64]*openWindow
+	sessions  map[interface{}][]*openWindow
+	ends      windowHeap
+	watermark time.Time
+}
+
+func newWindower(timestamp Timestamper, options WindowOptions) *windower {
+	if options.NewCollector == nil {
+		options.NewCollector = func() Collector {
+			return &windowSliceCollector{[]interface{}{}}
+		}
+	}
+	return &windower{
+		timestamp: timestamp,
+		options:   options,
+		starts:    map[int64]*openWindow{},
+		sessions:  map[interface{}][]*openWindow{},
+	}
+}
+
+// receive adds element to its windows, unless it is too late for them
+func (windower *windower) receive(element interface{}) {
+	var at time.Time
+	if windower.timestamp == nil {
+		at = time.Now()
+	} else {
+		at = windower.timestamp(element)
+	}
+	var key interface{}
+	if windower.key != nil {
+		key = windower.key(element)
+	}
+
+	windower.add(key, element, at)
+	if watermark := at.Add(-windower.options.AllowedLateness); watermark.After(windower.watermark) {
+		windower.watermark = watermark
+	}
+}
+
+func (windower *windower) addToWindow(key interface{}, start, end time.Time, element interface{}) {
+	if !end.After(windower.watermark) {
+		return
+	}
+	window, ok := windower.starts[start.UnixNano()]
+	if !ok {
+		window = &openWindow{key: key, start: start, end: end, collector: windower.options.NewCollector()}
+		windower.starts[start.UnixNano()] = window
+		heap.Push(&windower.ends, window)
+	}
+	window.collector.Add(element)
+}
+
+// addToSession merges the session [start, end) of element with every open
+// session of key that it overlaps
+func (windower *windower) addToSession(key interface{}, start, end time.Time, element interface{}) {
+	if !end.After(windower.watermark) {
+		return
+	}
+	merged := openWindow{key: key, start: start, end: end}
+	var others []*openWindow
+	for _, window := range windower.sessions[key] {
+		if window.start.Before(merged.end) && merged.start.Before(window.end) {
+			if window.start.Before(merged.start) {
+				merged.start = window.start
+			}
+			if window.end.After(merged.end) {
+				merged.end = window.end
+			}
+			merged.elements = append(merged.elements, window.elements...)
+			heap.Remove(&windower.ends, window.index)
+		} else {
+			others = append(others, window)
+		}
+	}
+	merged.elements = append(merged.elements, element)
+	windower.sessions[key] = append(others, &merged)
+	heap.Push(&windower.ends, &merged)
+}
+
+// closeWindows removes the windows that end at or before the watermark, or
+// all of them if all is true, and returns them ordered by end then start
+func (windower *windower) closeWindows(all bool) []Window {
+	var windows []Window
+	for len(windower.ends) > 0 && (all || !windower.ends[0].end.After(windower.watermark)) {
+		window := heap.Pop(&windower.ends).(*openWindow)
+		windower.forget(window)
+
+		collector := window.collector
+		if collector == nil {
+			collector = windower.options.NewCollector()
+			for _, element := range window.elements {
+				collector.Add(element)
+			}
+		}
+		windows = append(windows, Window{window.key, window.start, window.end, collector.Complete()})
+	}
+	return windows
+}
+
+// forget removes a closed window from starts or sessions
+func (windower *windower) forget(closed *openWindow) {
+	if closed.collector != nil {
+		delete(windower.starts, closed.start.UnixNano())
+		return
+	}
+	sessions := windower.sessions[closed.key]
+	for index, window := range sessions {
+		if window == closed {
+			sessions = append(sessions[:index], sessions[index+1:]...)
+			break
+		}
+	}
+	if len(sessions) == 0 {
+		delete(windower.sessions, closed.key)
+	} else {
+		windower.sessions[closed.key] = sessions
+	}
+}
+
+// nextClose returns how long until the earliest open window closes on the
+// clock, and false if there are no open windows
+func (windower *windower) nextClose() (time.Duration, bool) {
+	if len(windower.ends) == 0 {
+		return 0, false
+	}
+	return time.Until(windower.ends[0].end.Add(windower.options.AllowedLateness)), true
+}
+
+// windowHeap is a container/heap of open windows, ordered by end then start
+type windowHeap []*openWindow
+
+func (windows windowHeap) Len() int {
+	return len(windows)
+}
+
+func (windows windowHeap) Less(i, j int) bool {
+	if !windows[i].end.Equal(windows[j].end) {
+		return windows[i].end.Before(windows[j].end)
+	}
+	return windows[i].start.Before(windows[j].start)
+}
+
+func (windows windowHeap) Swap(i, j int) {
+	windows[i], windows[j] = windows[j], windows[i]
+	windows[i].index, windows[j].index = i, j
+}
+
+func (windows *windowHeap) Push(window interface{}) {
+	asWindow := window.(*openWindow)
+	asWindow.index = len(*windows)
+	*windows = append(*windows, asWindow)
+}
+
+func (windows *windowHeap) Pop() interface{} {
+	old := *windows
+	last := old[len(old)-1]
+	*windows = old[:len(old)-1]
+	return last
+}
+
+func (streams *Streams) addWindowStage(windower *windower) *Streams {
+	current, next := addNewStream(streams)
+	life := streams.lifetime()
+	processingTime := windower.timestamp == nil
+
+	go func() {
+		defer close(next)
+		emit := func(windows []Window) bool {
+			for _, window := range windows {
+				if !life.send(next, window) {
+					return false
+				}
+			}
+			return true
+		}
+
+		// with processing time, windows close on the clock, so the stage
+		// also wakes up when the earliest open window is due to close
+		var timer *time.Timer
+		var deadline <-chan time.Time
+		resetTimer := func() {
+			if !processingTime {
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer, deadline = nil, nil
+			if wait, ok := windower.nextClose(); ok {
+				timer = time.NewTimer(wait)
+				deadline = timer.C
+			}
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case element, ok := <-current:
+				if !ok {
+					emit(windower.closeWindows(true))
+					return
+				}
+				windower.receive(element)
+			case <-deadline:
+				windower.watermark = time.Now().Add(-windower.options.AllowedLateness)
+			case <-life.ctx.Done():
+				return
+			case <-life.stop:
+				return
+			}
+			if !emit(windower.closeWindows(false)) {
+				return
+			}
+			resetTimer()
+		}
+	}()
+
+	return streams
+}
+
+// windowSliceCollector is the default Collector of the windowing stages
+type windowSliceCollector struct {
+	collection []interface{}
+}
+
+func (collector *windowSliceCollector) Add(element interface{}) {
+	collector.collection = append(collector.collection, element)
+}
+
+func (collector *windowSliceCollector) Complete() interface{} {
+	return collector.collection
+}
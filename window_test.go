@@ -0,0 +1,174 @@
+package streams
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Event is a test element that happened At seconds after the epoch
+type Event struct {
+	At   int64
+	User string
+}
+
+func EventTime(element interface{}) time.Time {
+	return time.Unix(element.(Event).At, 0)
+}
+
+func EventUser(element interface{}) interface{} {
+	return element.(Event).User
+}
+
+func at(seconds int64) time.Time {
+	return time.Unix(seconds, 0)
+}
+
+func events(times ...int64) []interface{} {
+	events := make([]interface{}, len(times))
+	for index, at := range times {
+		events[index] = Event{At: at, User: "a"}
+	}
+	return events
+}
+
+type WindowCase struct {
+	Start    []interface{}
+	Options  WindowOptions
+	Expected []interface{}
+}
+
+func TestStreams_TumblingWindow(t *testing.T) {
+	cases := []WindowCase{
+		{[]interface{}{}, WindowOptions{}, []interface{}{}},
+		{
+			events(0, 5, 10, 25),
+			WindowOptions{},
+			[]interface{}{
+				Window{nil, at(0), at(10), events(0, 5)},
+				Window{nil, at(10), at(20), events(10)},
+				Window{nil, at(20), at(30), events(25)},
+			},
+		}, {
+			// 5 arrives after 10 closed the first window, so it is discarded
+			events(0, 10, 5),
+			WindowOptions{},
+			[]interface{}{
+				Window{nil, at(0), at(10), events(0)},
+				Window{nil, at(10), at(20), events(10)},
+			},
+		}, {
+			// allowing 5 seconds of lateness keeps the first window open long enough
+			events(0, 10, 5, 15, 3),
+			WindowOptions{AllowedLateness: 5 * time.Second},
+			[]interface{}{
+				Window{nil, at(0), at(10), events(0, 5)},
+				Window{nil, at(10), at(20), events(10, 15)},
+			},
+		}, {
+			events(0, 5, 10),
+			WindowOptions{NewCollector: func() Collector { return &countingCollector{} }},
+			[]interface{}{
+				Window{nil, at(0), at(10), 2},
+				Window{nil, at(10), at(20), 1},
+			},
+		},
+	}
+
+	for _, caze := range cases {
+		actual := FromCollection(caze.Start).
+			TumblingWindowWith(10*time.Second, EventTime, caze.Options).
+			Collect(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Expected, actual)
+	}
+}
+
+type countingCollector struct {
+	count int
+}
+
+func (collector *countingCollector) Add(_ interface{}) {
+	collector.count++
+}
+
+func (collector *countingCollector) Complete() interface{} {
+	return collector.count
+}
+
+func TestStreams_SlidingWindow(t *testing.T) {
+	actual := FromCollection(events(0, 6, 12)).
+		SlidingWindow(10*time.Second, 5*time.Second, EventTime).
+		Collect(collectors.NewSliceCollector())
+
+	expected := []interface{}{
+		Window{nil, at(-5), at(5), events(0)},
+		Window{nil, at(0), at(10), events(0, 6)},
+		Window{nil, at(5), at(15), events(6, 12)},
+		Window{nil, at(10), at(20), events(12)},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestStreams_SessionWindow(t *testing.T) {
+	start := []interface{}{
+		Event{0, "a"},
+		Event{1, "b"},
+		Event{3, "a"},
+		Event{20, "a"},
+		// bridges the gap between the a sessions at 3 and 20 once it arrives,
+		// since 10 seconds of lateness keeps the first one open
+		Event{12, "a"},
+		Event{40, "b"},
+	}
+
+	actual := FromCollection(start).
+		SessionWindowWith(10*time.Second, EventUser, EventTime, WindowOptions{AllowedLateness: 10 * time.Second}).
+		Collect(collectors.NewSliceCollector())
+
+	expected := []interface{}{
+		Window{"b", at(1), at(11), []interface{}{Event{1, "b"}}},
+		Window{"a", at(0), at(30), []interface{}{Event{0, "a"}, Event{3, "a"}, Event{20, "a"}, Event{12, "a"}}},
+		Window{"b", at(40), at(50), []interface{}{Event{40, "b"}}},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestStreams_SessionWindowManyKeys(t *testing.T) {
+	// each user's session ends 10 seconds after its only event, so they close
+	// in order of their events as later ones arrive
+	start := []interface{}{}
+	expected := []interface{}{}
+	for user := 0; user < 100; user++ {
+		event := Event{int64(user * 3), strconv.Itoa(user)}
+		start = append(start, event)
+		expected = append(expected, Window{event.User, at(event.At), at(event.At + 10), []interface{}{event}})
+	}
+
+	actual := FromCollection(start).
+		SessionWindow(10*time.Second, EventUser, EventTime).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestStreams_TumblingWindowProcessingTime(t *testing.T) {
+	source := make(Stream)
+	stream := FromStream(source, 0).TumblingWindow(20*time.Millisecond, nil)
+
+	source <- 1
+	// the window closes on the clock, without waiting for more elements
+	window := (<-stream.lastStream()).(Window)
+	close(source)
+
+	assert.Equal(t, []interface{}{1}, window.Contents)
+	assert.Equal(t, 20*time.Millisecond, window.End.Sub(window.Start))
+}
+
+func TestStreams_WindowPanicsOnBadSize(t *testing.T) {
+	assert.Panics(t, func() { FromCollection(nil).TumblingWindow(0, EventTime) })
+	assert.Panics(t, func() { FromCollection(nil).SessionWindow(0, EventUser, EventTime) })
+}
@@ -0,0 +1,92 @@
+package streams
+
+import (
+	"errors"
+)
+
+// ErrUnevenZip is the error reported by ZipWithEnd with ErrorOnUneven
+// when one of the zipped streams ends before the other
+var ErrUnevenZip = errors.New("streams: zipped streams have different lengths")
+
+// ZipEnd decides what ZipWithEnd does when one stream ends before the other
+type ZipEnd int
+
+const (
+	// StopAtShortest stops once either stream ends. The rest of the other
+	// stream is discarded.
+	StopAtShortest ZipEnd = iota
+	// PadWithNil keeps going until both streams end, pairing the rest of the
+	// longer stream with nil.
+	PadWithNil
+	// ErrorOnUneven stops with ErrUnevenZip if one stream ends before the other.
+	// When the streams Zip was called on ends, it waits for one more element
+	// or the end of the other stream to tell which it is.
+	ErrorOnUneven
+)
+
+// Pair is the element type of a zipped stream. First comes from the streams
+// Zip was called on and Second from the other streams.
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+// Zipper combines an element from each of two zipped streams into one element
+type Zipper func(first, second interface{}) interface{}
+
+// Zip returns a new streams object of Pairs, made of the first element of
+// streams and of other, then the second elements, and so on. It stops at the
+// end of the shorter stream. See Concat for how the new streams object is set up.
+func (streams *Streams) Zip(other *Streams) *Streams {
+	return streams.ZipWith(other, func(first, second interface{}) interface{} {
+		return Pair{first, second}
+	})
+}
+
+// ZipWith is Zip, but each pair of elements is combined using combiner
+// instead of being put in a Pair.
+func (streams *Streams) ZipWith(other *Streams, combiner Zipper) *Streams {
+	return streams.ZipWithEnd(other, combiner, StopAtShortest)
+}
+
+// ZipWithEnd is ZipWith, with end deciding what happens when one of the
+// streams ends before the other.
+func (streams *Streams) ZipWithEnd(other *Streams, combiner Zipper, end ZipEnd) *Streams {
+	return streams.combine([]*Streams{other}, func(life lifetime, inputs []Stream, next Stream, failed func(input Stream) bool) error {
+		for {
+			first, firstOk := life.receive(inputs[0])
+			// a failed stream ends early, which is its error and not an uneven end
+			if life.stopped() || (!firstOk && failed(inputs[0])) {
+				return nil
+			}
+			if !firstOk && end != PadWithNil {
+				return zipEnded(life, inputs[1], end)
+			}
+			second, secondOk := life.receive(inputs[1])
+			if life.stopped() || (!secondOk && failed(inputs[1])) || (!firstOk && !secondOk) {
+				return nil
+			}
+			if !secondOk && end != PadWithNil {
+				return zipEnded(life, nil, end)
+			}
+			if !life.send(next, combiner(first, second)) {
+				return nil
+			}
+		}
+	})
+}
+
+// zipEnded returns what ZipWithEnd returns when one of the streams has ended
+// and end is not PadWithNil. For ErrorOnUneven, other is the stream that may
+// not have ended yet, or nil if both are known to be uneven.
+func zipEnded(life lifetime, other Stream, end ZipEnd) error {
+	if end == StopAtShortest {
+		return nil
+	}
+	if other != nil {
+		if _, ok := life.receive(other); !ok || life.stopped() {
+			return nil
+		}
+	}
+	return ErrUnevenZip
+}
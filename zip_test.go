@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Luke-Sikina/streams/collectors"
+	"github.com/stretchr/testify/assert"
+)
+
+type ZipCase struct {
+	First    []interface{}
+	Second   []interface{}
+	End      ZipEnd
+	Expected []interface{}
+	Err      error
+}
+
+func TestStreams_Zip(t *testing.T) {
+	actual := FromCollection([]interface{}{1, 2, 3}).
+		Zip(FromCollection([]interface{}{"a", "b"})).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{Pair{1, "a"}, Pair{2, "b"}}, actual)
+}
+
+func TestStreams_ZipWith(t *testing.T) {
+	actual := FromCollection([]interface{}{1, 2, 3}).
+		ZipWith(FromCollection([]interface{}{10, 20, 30}), ReduceToSum).
+		Collect(collectors.NewSliceCollector())
+
+	assert.Equal(t, []interface{}{11, 22, 33}, actual)
+}
+
+func TestStreams_ZipWithEnd(t *testing.T) {
+	cases := []ZipCase{
+		{[]interface{}{}, []interface{}{}, ErrorOnUneven, []interface{}{}, nil},
+		{[]interface{}{1, 2}, []interface{}{"a"}, StopAtShortest, []interface{}{Pair{1, "a"}}, nil},
+		{[]interface{}{1}, []interface{}{"a", "b"}, StopAtShortest, []interface{}{Pair{1, "a"}}, nil},
+		{[]interface{}{1, 2}, []interface{}{"a"}, PadWithNil, []interface{}{Pair{1, "a"}, Pair{2, nil}}, nil},
+		{[]interface{}{1}, []interface{}{"a", "b"}, PadWithNil, []interface{}{Pair{1, "a"}, Pair{nil, "b"}}, nil},
+		{[]interface{}{1}, []interface{}{"a"}, ErrorOnUneven, []interface{}{Pair{1, "a"}}, nil},
+		{[]interface{}{1, 2}, []interface{}{"a"}, ErrorOnUneven, nil, ErrUnevenZip},
+		{[]interface{}{1}, []interface{}{"a", "b"}, ErrorOnUneven, nil, ErrUnevenZip},
+	}
+	pair := func(first, second interface{}) interface{} { return Pair{first, second} }
+
+	for _, caze := range cases {
+		actual, err := FromCollection(caze.First).
+			ZipWithEnd(FromCollection(caze.Second), pair, caze.End).
+			CollectErr(collectors.NewSliceCollector())
+
+		assert.Equal(t, caze.Err, err)
+		if caze.Err == nil {
+			assert.Equal(t, caze.Expected, actual)
+		}
+	}
+}
+
+func TestStreams_ZipWithEnd_FailedStream(t *testing.T) {
+	pair := func(first, second interface{}) interface{} { return Pair{first, second} }
+	failing := func() *Streams { return FromCollection([]interface{}{1, 2, 3, 4}).MapErr(FailOnThree) }
+	other := func() *Streams { return FromCollection([]interface{}{"a", "b", "c", "d"}) }
+
+	// the failed stream ends early, but its error is reported rather than ErrUnevenZip
+	_, err := failing().ZipWithEnd(other(), pair, ErrorOnUneven).CollectErr(collectors.NewSliceCollector())
+	assert.Equal(t, errTest, err)
+	_, err = other().ZipWithEnd(failing(), pair, ErrorOnUneven).CollectErr(collectors.NewSliceCollector())
+	assert.Equal(t, errTest, err)
+}
+
+func TestStreams_ZipWithEnd_IdleOther(t *testing.T) {
+	idle := make(Stream)
+	done := make(chan interface{})
+
+	go func() {
+		defer close(done)
+		actual := FromCollection([]interface{}{}).
+			Zip(FromStream(idle, 0)).
+			Collect(collectors.NewSliceCollector())
+		assert.Equal(t, []interface{}{}, actual)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("zip waited for the idle stream after the first stream ended")
+	}
+}